@@ -36,6 +36,8 @@ export const api = {
     listCompanies: () => apiFetch("/companies"),
     addCompany: (body) => 
         apiFetch("/companies", { method: "POST", body: JSON.stringify(body) }),
+    detectCompany: (url) =>
+        apiFetch("/companies/detect", { method: "POST", body: JSON.stringify({ url }) }),
     deleteCompany: (id) => apiFetch(`/companies/${id}`, { method: "DELETE" }),
 
     // JobCart
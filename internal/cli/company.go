@@ -1,14 +1,20 @@
 package cli
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/scraper"
+	"github.com/Trungsherlock/jobgo/internal/worker"
 	"github.com/spf13/cobra"
 )
 
@@ -24,12 +30,36 @@ var companyAddCmd = &cobra.Command{
 		name, _ := cmd.Flags().GetString("name")
 		platform, _ := cmd.Flags().GetString("platform")
 		slug, _ := cmd.Flags().GetString("slug")
+		careerURL, _ := cmd.Flags().GetString("url")
+
+		if platform == "" && careerURL != "" {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			d, err := scraper.NewRegistry().Detect(ctx, careerURL)
+			if err != nil {
+				return fmt.Errorf("detecting platform: %w", err)
+			}
+			platform, careerURL = d.Platform, d.CareerURL
+			if slug == "" {
+				slug = d.Slug
+			}
+			fmt.Printf("Detected platform %s (slug: %s)\n", platform, slug)
+		}
+
+		if crawlsCareerURL(platform) {
+			if careerURL == "" {
+				return fmt.Errorf("--url is required for the %s platform", platform)
+			}
+			if slug == "" {
+				slug = scraper.SlugFromCareerURL(careerURL)
+			}
+		}
 
 		if name == "" || platform == "" || slug == "" {
-			return fmt.Errorf("--name, --platform, and --slug are required")
+			return fmt.Errorf("--name and either --url or --platform and --slug are required")
 		}
 
-		company, err := db.CreateCompany(name, platform, slug, "")
+		company, err := db.CreateCompany(name, platform, slug, careerURL)
 		if err != nil {
 			return fmt.Errorf("adding company: %w", err)
 		}
@@ -40,7 +70,7 @@ var companyAddCmd = &cobra.Command{
 
 var companyImportCmd = &cobra.Command{
 	Use:   "import <csv-file>",
-	Short: "Bulk import companies from a CSV file (columns: name,platform,slug)",
+	Short: "Bulk import companies from a CSV file (columns: name,platform,slug[,career_url])",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		f, err := os.Open(args[0])
@@ -51,6 +81,7 @@ var companyImportCmd = &cobra.Command{
 
 		reader := csv.NewReader(f)
 		reader.TrimLeadingSpace = true
+		reader.FieldsPerRecord = -1
 
 		// Skip header row
 		if _, err := reader.Read(); err != nil {
@@ -71,13 +102,20 @@ var companyImportCmd = &cobra.Command{
 			name := strings.TrimSpace(record[0])
 			platform := strings.TrimSpace(record[1])
 			slug := strings.TrimSpace(record[2])
+			careerURL := ""
+			if len(record) > 3 {
+				careerURL = strings.TrimSpace(record[3])
+			}
+			if slug == "" && crawlsCareerURL(platform) {
+				slug = scraper.SlugFromCareerURL(careerURL)
+			}
 
 			if name == "" || platform == "" || slug == "" {
 				skipped++
 				continue
 			}
 
-			_, err = db.CreateCompany(name, platform, slug, "")
+			_, err = db.CreateCompany(name, platform, slug, careerURL)
 			if err != nil {
 				fmt.Printf("  SKIP  %s: %v\n", name, err)
 				skipped++
@@ -114,13 +152,20 @@ var companyListCmd = &cobra.Command{
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintln(w, "ID\tNAME\tPLATFORM\tSLUG\tLAST SCRAPED")
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tPLATFORM\tSLUG\tLAST SCRAPED\tSTATUS")
 		for _, c := range companies {
 			lastScraped := "never"
 			if c.LastScrapedAt != nil {
 				lastScraped = c.LastScrapedAt.Format("2006-01-02 15:04")
 			}
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ID[:8], c.Name, c.Platform, c.Slug, lastScraped)
+			status := "-"
+			if c.VerifyStatus != nil {
+				status = *c.VerifyStatus
+			}
+			if !c.Enabled {
+				status += " (disabled)"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", c.ID[:8], c.Name, c.Platform, c.Slug, lastScraped, status)
 		}
 		_ = w.Flush()
 		return nil
@@ -140,14 +185,215 @@ var companyRemoveCmd = &cobra.Command{
 	},
 }
 
+var companyTestScrapeCmd = &cobra.Command{
+	Use:   "test-scrape <id>",
+	Short: "Run a company's scraper and print the parsed jobs without storing them",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		company, err := db.FindCompany(args[0])
+		if err != nil {
+			return err
+		}
+
+		s, err := scraper.NewRegistry().Get(company.Platform)
+		if err != nil {
+			return err
+		}
+
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		jobs, err := s.FetchJobs(ctx, scraper.Target(s, company.Slug, company.CareerURL))
+		if scraper.IsPartial(err) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else if err != nil {
+			return fmt.Errorf("scraping %s: %w", company.Name, err)
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "json" {
+			data, _ := json.MarshalIndent(jobs, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TITLE\tLOCATION\tDEPARTMENT\tPOSTED\tURL")
+		for _, j := range jobs {
+			title := j.Title
+			if len(title) > 45 {
+				title = title[:42] + "..."
+			}
+			posted := "-"
+			if j.PostedAt != nil {
+				posted = j.PostedAt.Format("2006-01-02")
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", title, j.Location, j.Department, posted, j.URL)
+		}
+		_ = w.Flush()
+		fmt.Printf("\n%d jobs parsed from %s (%s). Nothing was stored.\n", len(jobs), company.Name, company.Platform)
+		return nil
+	},
+}
+
+var companyVerifyCmd = &cobra.Command{
+	Use:   "verify [id]",
+	Short: "Check that company slugs resolve to a live job board",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		disableAfter, _ := cmd.Flags().GetInt("disable-after")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		var companies []database.Company
+		switch {
+		case len(args) == 1:
+			c, err := db.FindCompany(args[0])
+			if err != nil {
+				return err
+			}
+			companies = append(companies, *c)
+		case all:
+			var err error
+			companies, err = db.ListCompanies()
+			if err != nil {
+				return fmt.Errorf("listing companies: %w", err)
+			}
+		default:
+			return fmt.Errorf("pass a company id or --all")
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		pool := worker.NewPool(scraper.NewRegistry(), db, 5)
+		results := pool.Verify(ctx, companies)
+		sort.Slice(results, func(i, j int) bool { return results[i].Company.Name < results[j].Company.Name })
+
+		output, _ := cmd.Flags().GetString("output")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if output != "json" {
+			_, _ = fmt.Fprintln(w, "ID\tNAME\tPLATFORM\tSLUG\tSTATUS\tJOBS\tDETAIL")
+		}
+
+		type verifyRow struct {
+			ID       string `json:"id"`
+			Name     string `json:"name"`
+			Status   string `json:"status"`
+			JobCount int    `json:"job_count"`
+			Error    string `json:"error,omitempty"`
+			Disabled bool   `json:"disabled,omitempty"`
+		}
+		var rows []verifyRow
+		counts := make(map[worker.VerifyStatus]int)
+		for _, v := range results {
+			c := v.Company
+			counts[v.Status]++
+			errMsg := ""
+			if v.Err != nil {
+				errMsg = v.Err.Error()
+			}
+
+			notFound, err := db.RecordCompanyVerification(c.ID, string(v.Status), errMsg)
+			if err != nil {
+				return err
+			}
+			disabled := false
+			if disableAfter > 0 && notFound >= disableAfter && c.Enabled {
+				if err := db.SetCompanyEnabled(c.ID, false); err != nil {
+					return fmt.Errorf("disabling %s: %w", c.Name, err)
+				}
+				disabled = true
+			}
+
+			rows = append(rows, verifyRow{ID: c.ID, Name: c.Name, Status: string(v.Status), JobCount: v.JobCount, Error: errMsg, Disabled: disabled})
+
+			detail := errMsg
+			if disabled {
+				detail = fmt.Sprintf("disabled after %d not-found checks", notFound)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", c.ID[:8], c.Name, c.Platform, c.Slug, v.Status, v.JobCount, detail)
+		}
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(rows, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		_ = w.Flush()
+
+		fmt.Printf("\n%d ok, %d not found, %d empty, %d rate limited, %d network errors, %d other errors\n",
+			counts[worker.VerifyOK], counts[worker.VerifyNotFound], counts[worker.VerifyEmpty],
+			counts[worker.VerifyRateLimited], counts[worker.VerifyNetworkError], counts[worker.VerifyError])
+		return nil
+	},
+}
+
+var companyEnableCmd = &cobra.Command{
+	Use:   "enable <id>",
+	Short: "Re-enable scraping for a company",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setCompanyEnabled(args[0], true)
+	},
+}
+
+var companyDisableCmd = &cobra.Command{
+	Use:   "disable <id>",
+	Short: "Stop scraping a company without removing it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setCompanyEnabled(args[0], false)
+	},
+}
+
+func setCompanyEnabled(idPrefix string, enabled bool) error {
+	c, err := db.FindCompany(idPrefix)
+	if err != nil {
+		return err
+	}
+	if err := db.SetCompanyEnabled(c.ID, enabled); err != nil {
+		return fmt.Errorf("updating company: %w", err)
+	}
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	fmt.Printf("%s %s.\n", c.Name, state)
+	return nil
+}
+
+// crawlsCareerURL reports whether platform scrapes the company's career URL
+// rather than an ATS slug.
+func crawlsCareerURL(platform string) bool {
+	s, err := scraper.NewRegistry().Get(platform)
+	if err != nil {
+		return false
+	}
+	_, ok := s.(scraper.CareerPageScraper)
+	return ok
+}
+
 func init() {
 	rootCmd.AddCommand(companyCmd)
 	companyCmd.AddCommand(companyAddCmd)
 	companyCmd.AddCommand(companyImportCmd)
 	companyCmd.AddCommand(companyRemoveCmd)
 	companyCmd.AddCommand(companyListCmd)
+	companyCmd.AddCommand(companyTestScrapeCmd)
+	companyCmd.AddCommand(companyVerifyCmd)
+	companyCmd.AddCommand(companyEnableCmd)
+	companyCmd.AddCommand(companyDisableCmd)
 
 	companyAddCmd.Flags().String("name", "", "Company name")
-	companyAddCmd.Flags().String("platform", "", "ATS platform (lever, greenhouse)")
+	companyAddCmd.Flags().String("platform", "", "ATS platform (lever, greenhouse, ashby, workday, smartrecruiters, workable, recruitee, jsonld, html, or an installed plugin)")
 	companyAddCmd.Flags().String("slug", "", "Platform slug")
+	companyAddCmd.Flags().String("url", "", "Careers URL; platform and slug are detected from it when --platform is omitted")
+
+	companyTestScrapeCmd.Flags().Duration("timeout", 2*time.Minute, "Scrape timeout")
+
+	companyVerifyCmd.Flags().Bool("all", false, "Verify every tracked company")
+	companyVerifyCmd.Flags().Int("disable-after", 0, "Disable companies after this many consecutive not-found checks (0 = never)")
+	companyVerifyCmd.Flags().Duration("timeout", 5*time.Minute, "Overall verification timeout")
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
@@ -8,9 +9,19 @@ import (
 	"text/tabwriter"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/dedup"
 	"github.com/Trungsherlock/jobgo/internal/filter"
+	"github.com/Trungsherlock/jobgo/internal/freshness"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
+	"github.com/Trungsherlock/jobgo/internal/geo"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
+	"github.com/Trungsherlock/jobgo/internal/htmltext"
+	"github.com/Trungsherlock/jobgo/internal/salary"
+	"github.com/Trungsherlock/jobgo/internal/textdiff"
 )
 
 var jobsCmd = &cobra.Command{
@@ -20,7 +31,7 @@ var jobsCmd = &cobra.Command{
 
 var jobsListCmd = &cobra.Command{
 	Use:	"list",
-	Short:	"List jobs sorted by match score",
+	Short:	"List jobs, best ranked first",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		minScore, _ := cmd.Flags().GetFloat64("min-score")
 		company, _ := cmd.Flags().GetString("company")
@@ -29,9 +40,23 @@ var jobsListCmd = &cobra.Command{
 		locationFlag, _ := cmd.Flags().GetString("location")
 		h1bOnly, _ := cmd.Flags().GetBool("h1b")
 		newGradOnly, _ := cmd.Flags().GetBool("new-grad")
+		includeClosed, _ := cmd.Flags().GetBool("include-closed")
+		expand, _ := cmd.Flags().GetBool("expand")
+		minSalary, _ := cmd.Flags().GetFloat64("min-salary")
+		salaryCurrency, _ := cmd.Flags().GetString("salary-currency")
+		employmentType, _ := cmd.Flags().GetString("employment-type")
+		excludeRestricted, _ := cmd.Flags().GetBool("exclude-restricted")
+		postedWithin, _ := cmd.Flags().GetString("posted-within")
+		sortKey, _ := cmd.Flags().GetString("sort")
 
-
-		jobs, err := db.ListJobs(minScore, company, onlyNew, false, false, false, false)
+		jobs, err := db.QueryJobs(database.JobQuery{
+			MinScore:      minScore,
+			CompanyID:     company,
+			OnlyNew:       onlyNew,
+			IncludeClosed: includeClosed,
+			MinSalary:     minSalary,
+			SalaryCurrency: salaryCurrency,
+		})
 		if err != nil {
 			return fmt.Errorf("listing jobs: %w", err)
 		}	
@@ -43,8 +68,17 @@ var jobsListCmd = &cobra.Command{
 		if locationFlag != "" {
 			params.Locations = strings.Split(locationFlag, ",")
 		}
+		if employmentType != "" {
+			params.EmploymentTypes = strings.Split(employmentType, ",")
+		}
 		params.NewGrad = newGradOnly
 		params.H1BOnly = h1bOnly
+		params.ExcludeRestricted = excludeRestricted
+		if postedWithin != "" {
+			if params.PostedWithin, err = freshness.ParseAge(postedWithin); err != nil {
+				return err
+			}
+		}
 
 		var sponsorIDs map[string]bool
 		if h1bOnly {
@@ -58,26 +92,55 @@ var jobsListCmd = &cobra.Command{
 		}
 
 		jobs = filter.Apply(jobs, filter.Build(params, sponsorIDs))
+		now := time.Now()
+		if jobs, err = ranking.Sort(jobs, sortKey, now); err != nil {
+			return err
+		}
 
 		if len(jobs) == 0 {
 			fmt.Println("No jobs found matching the criteria.")
 			return nil
 		}
 
+		// Duplicates collapse into one row per group; --expand lists the
+		// rest of each group beneath it.
+		members := make(map[string][]database.Job)
+		for _, j := range jobs {
+			key := dedup.GroupKey(j)
+			members[key] = append(members[key], j)
+		}
+		rows := dedup.Collapse(jobs)
+
 		output, _ := cmd.Flags().GetString("output")
 		if output == "json" {
-			data, _ := json.MarshalIndent(jobs, "", "  ")
+			if expand {
+				rows = jobs
+			}
+			data, _ := json.MarshalIndent(rows, "", "  ")
 			fmt.Println(string(data))
 			return nil
 		}
 
+		var listed []database.Job
+		for _, j := range rows {
+			listed = append(listed, j)
+			if expand {
+				listed = append(listed, members[dedup.GroupKey(j)][1:]...)
+			}
+		}
+
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintln(w, "ID\tSCORE\tTITLE\tCOMPANY\tLOCATION\tSTATUS")
-		for _, j := range jobs {
+		_, _ = fmt.Fprintln(w, "ID\tSCORE\tSKILL\tTITLE\tCOMPANY\tLOCATION\tSALARY\tAGE\tSTATUS")
+		halfLife := freshness.HalfLife()
+		for i, j := range listed {
 			id := j.ID
 			score := "-"
+			if v, ok := ranking.Current(j, halfLife, now); ok {
+				score = fmt.Sprintf("%.0f", v)
+			}
+			skill := "-"
 			if j.SkillScore != nil {
-				score = fmt.Sprintf("%.0f", *j.SkillScore)
+				skill = fmt.Sprintf("%.0f", *j.SkillScore)
 			}
 			location := ""
 			if j.Location != nil {
@@ -87,16 +150,40 @@ var jobsListCmd = &cobra.Command{
 			if len(title) > 45 {
 				title = title[:42] + "..."
 			}
+			if expand && i > 0 && dedup.GroupKey(j) == dedup.GroupKey(listed[i-1]) {
+				title = "  └ " + title
+			} else if !expand && j.DuplicateCount > 0 {
+				title += fmt.Sprintf(" (+%d)", j.DuplicateCount)
+			}
 			companyName := j.CompanyID[:8]
 			c, err := db.GetCompany(j.CompanyID)
 			if err == nil {
 				companyName = c.Name
 			}
 
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", id, score, title, companyName, location, j.Status)
+			status := j.Status
+			if j.ClosedAt != nil {
+				status += " (closed)"
+			}
+
+			pay := "-"
+			if r, ok := salary.FromJob(j); ok {
+				pay = r.String()
+			}
+
+			age := "-"
+			if d, ok := freshness.Age(j, now); ok {
+				age = freshness.FormatAge(d)
+			}
+
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", id, score, skill, title, companyName, location, pay, age, status)
 		}
 		_ = w.Flush()
-		fmt.Printf("\n%d jobs total\n", len(jobs))
+		if len(rows) < len(jobs) {
+			fmt.Printf("\n%d jobs total (%d postings; duplicates grouped, use --expand to list them)\n", len(rows), len(jobs))
+		} else {
+			fmt.Printf("\n%d jobs total\n", len(jobs))
+		}
 		return nil
 	},
 }
@@ -111,6 +198,23 @@ var jobsShowCmd = &cobra.Command{
 			return fmt.Errorf("getting job: %w", err)
 		}
 
+		if raw, _ := cmd.Flags().GetBool("raw"); raw {
+			payload, err := db.GetJobPayload(job.ID)
+			if err != nil {
+				return err
+			}
+			if payload == nil {
+				return fmt.Errorf("no raw payload stored for job %s; it is kept from the next scrape on", job.ID)
+			}
+			var out bytes.Buffer
+			if json.Indent(&out, payload, "", "  ") != nil {
+				out.Reset()
+				out.Write(payload)
+			}
+			fmt.Println(out.String())
+			return nil
+		}
+
 		// Get company name
 		companyName := job.CompanyID
 		c, err := db.GetCompany(job.CompanyID)
@@ -123,13 +227,44 @@ var jobsShowCmd = &cobra.Command{
 		if job.Location != nil {
 			fmt.Printf("Location:    %s\n", *job.Location)
 		}
+		if places := geo.PlacesOf(*job); len(places) > 0 {
+			fmt.Printf("Places:      %s\n", geo.Describe(places))
+		}
 		fmt.Printf("Remote:      %v\n", job.Remote)
+		if job.EmploymentType != nil {
+			fmt.Printf("Type:        %s\n", *job.EmploymentType)
+		}
+		for i, r := range h1b.RestrictionsOf(*job) {
+			label := ""
+			if i == 0 {
+				label = "Restricted:"
+			}
+			fmt.Printf("%-12s %s — %q\n", label, h1b.DescribeRestriction(r), r.Evidence)
+		}
+		if r, ok := salary.FromJob(*job); ok {
+			fmt.Printf("Salary:      %s\n", r.String())
+		}
 		if job.Department != nil {
 			fmt.Printf("Department:  %s\n", *job.Department)
 		}
 		fmt.Printf("URL:         %s\n", job.URL)
 		fmt.Printf("Status:      %s\n", job.Status)
+		if job.PostedAt != nil {
+			fmt.Printf("Posted:      %s\n", job.PostedAt.Format("2006-01-02 15:04"))
+		}
+		if job.FirstSeenAt != nil {
+			fmt.Printf("First seen:  %s\n", job.FirstSeenAt.Format("2006-01-02 15:04"))
+		}
+		if job.PlatformUpdatedAt != nil {
+			fmt.Printf("Updated:     %s (on the board)\n", job.PlatformUpdatedAt.Format("2006-01-02 15:04"))
+		}
+		if job.ClosedAt != nil {
+			fmt.Printf("Closed:      %s (no longer on the board)\n", job.ClosedAt.Format("2006-01-02 15:04"))
+		}
 
+		if v, ok := ranking.Current(*job, freshness.HalfLife(), time.Now()); ok && job.FinalScore != nil {
+			fmt.Printf("Final Score: %.0f (%s)\n", v, ranking.Describe(job.ScoreBreakdown))
+		}
 		if job.SkillScore != nil {
 			fmt.Printf("Skill Score: %.0f\n", *job.SkillScore)
 		}
@@ -143,6 +278,20 @@ var jobsShowCmd = &cobra.Command{
 			fmt.Printf("Missing:	%s\n", *job.SkillMissing)
 		}
 
+		if group, err := db.ListJobGroup(job.ID); err == nil && len(group) > 1 {
+			fmt.Printf("\n--- Also posted as ---\n")
+			for _, g := range group {
+				if g.ID == job.ID {
+					continue
+				}
+				location := ""
+				if g.Location != nil {
+					location = *g.Location
+				}
+				fmt.Printf("  %s  %s  %s  %s\n", g.ID, g.CreatedAt.Format("2006-01-02"), g.Title, location)
+			}
+		}
+
 		if job.Description != nil {
 			fmt.Printf("\n--- Description ---\n%s\n", *job.Description)
 		}
@@ -160,6 +309,71 @@ var jobsShowCmd = &cobra.Command{
 	},
 }
 
+var jobsHistoryCmd = &cobra.Command{
+	Use:	"history [id]",
+	Short:	"Show how a job posting changed between scrapes",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		job, err := db.GetJob(args[0])
+		if err != nil {
+			return fmt.Errorf("getting job: %w", err)
+		}
+		revs, err := db.ListJobRevisions(job.ID)
+		if err != nil {
+			return err
+		}
+
+		// Each revision holds the content that was replaced at CapturedAt,
+		// so the current version is the job itself.
+		versions := append(revs, database.JobRevision{
+			JobID:       job.ID,
+			Title:       job.Title,
+			Description: deref(job.Description),
+			Location:    deref(job.Location),
+			Department:  deref(job.Department),
+		})
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "json" {
+			data, _ := json.MarshalIndent(versions, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("%s\n", job.Title)
+		if len(revs) == 0 {
+			fmt.Printf("No changes since first seen on %s.\n", job.CreatedAt.Format("2006-01-02 15:04"))
+			return nil
+		}
+		fmt.Printf("First seen %s, changed %d times.\n", job.CreatedAt.Format("2006-01-02 15:04"), len(revs))
+
+		for i := 1; i < len(versions); i++ {
+			prev, cur := versions[i-1], versions[i]
+			fmt.Printf("\n=== Version %d -> %d (%s) ===\n", i, i+1, revs[i-1].CapturedAt.Format("2006-01-02 15:04"))
+			for _, f := range []struct{ name, old, new string }{
+				{"Title", prev.Title, cur.Title},
+				{"Location", prev.Location, cur.Location},
+				{"Department", prev.Department, cur.Department},
+			} {
+				if f.old != f.new {
+					fmt.Printf("%s:\n- %s\n+ %s\n", f.name, f.old, f.new)
+				}
+			}
+			if d := textdiff.Unified(htmltext.ToText(prev.Description), htmltext.ToText(cur.Description), 2); d != "" {
+				fmt.Printf("Description:\n%s", d)
+			}
+		}
+		return nil
+	},
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 var jobsOpenCmd = &cobra.Command{
 	Use:	"open",
 	Short:	"Opens the job URL in the default browser",
@@ -217,13 +431,24 @@ func init() {
 	jobsCmd.AddCommand(jobsShowCmd)
 	jobsCmd.AddCommand(jobsOpenCmd)
 	jobsCmd.AddCommand(jobsUpdateCmd)
+	jobsCmd.AddCommand(jobsHistoryCmd)
 
-	jobsListCmd.Flags().Float64("min-score", 0, "Minimum skill score (0-100)")
+	jobsListCmd.Flags().Float64("min-score", 0, "Minimum final score (0-100); unranked jobs are compared on their skill score")
 	jobsListCmd.Flags().String("company", "", "Filter by company ID")
 	jobsListCmd.Flags().Bool("new", false, "Only unseen jobs")
 	jobsListCmd.Flags().String("title", "", "Filter by title (e.g. 'software engineer,backend engineer')")
 	jobsListCmd.Flags().String("location", "", "Filter by location (e.g. 'US,remote')")
 	jobsListCmd.Flags().Bool("new-grad", false, "Only new-grad friendly jobs")
 	jobsListCmd.Flags().Bool("h1b", false, "Only H1B-sponsoring companies")
+	jobsListCmd.Flags().Bool("include-closed", false, "Include postings that have been removed from their board")
+	jobsListCmd.Flags().Float64("min-salary", 0, "Minimum stated pay, annualized, in --salary-currency (hides jobs without a salary)")
+	jobsListCmd.Flags().String("salary-currency", "USD", "Currency of --min-salary; jobs paying in other currencies are hidden")
+	jobsListCmd.Flags().String("employment-type", "", "Filter by employment type (full_time, part_time, contract, intern; e.g. 'intern,contract')")
+	jobsListCmd.Flags().Bool("exclude-restricted", false, "Hide jobs requiring citizenship, a green card, a security clearance or export-control eligibility, or refusing sponsorship")
+	jobsListCmd.Flags().String("sort", "rank", "Sort by rank (final score), skill (skill score) or posted (newest first)")
+	jobsListCmd.Flags().String("posted-within", "", "Only jobs posted within this long (e.g. 7d, 2w, 36h); uses the first-seen time when the board gives no date")
+	jobsListCmd.Flags().Bool("expand", false, "List every posting in a duplicate group instead of one row per group")
 	jobsListCmd.Flags().String("output", "", "Output format: json")
+	jobsHistoryCmd.Flags().String("output", "", "Output format: json")
+	jobsShowCmd.Flags().Bool("raw", false, "Print the platform's raw payload for the job instead")
 }
\ No newline at end of file
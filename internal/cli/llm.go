@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/matcher"
+	"github.com/spf13/cobra"
+)
+
+var llmCmd = &cobra.Command{
+	Use:   "llm",
+	Short: "Inspect LLM scoring",
+}
+
+var llmUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show LLM requests, cache hits and tokens per day against the budget",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		daysBack, _ := cmd.Flags().GetInt("days")
+		if daysBack < 1 {
+			daysBack = 1
+		}
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+		days, err := db.LLMUsageByDay(today.AddDate(0, 0, -(daysBack - 1)))
+		if err != nil {
+			return err
+		}
+		usedToday, err := db.LLMTokensSince(today)
+		if err != nil {
+			return err
+		}
+		cached, err := db.CountLLMCache()
+		if err != nil {
+			return fmt.Errorf("counting LLM cache: %w", err)
+		}
+		cfg := matcher.LoadProviderConfig()
+		budget := matcher.LoadBudget()
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "json" {
+			if days == nil {
+				days = []database.LLMUsageDay{}
+			}
+			data, _ := json.MarshalIndent(struct {
+				Provider    string                 `json:"provider"`
+				Model       string                 `json:"model,omitempty"`
+				Budget      matcher.Budget         `json:"budget"`
+				TodayTokens int                    `json:"today_tokens"`
+				Cached      int                    `json:"cached_responses"`
+				Days        []database.LLMUsageDay `json:"days"`
+			}{cfg.Provider, cfg.Model, budget, usedToday, cached, days}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		provider := cfg.Provider
+		if cfg.Model != "" {
+			provider += " (" + cfg.Model + ")"
+		}
+		fmt.Printf("Provider: %s\n", provider)
+		fmt.Printf("Budget:   %s per run, %s per day\n", tokenLimit(budget.PerRun), tokenLimit(budget.PerDay))
+		fmt.Printf("Today:    %d tokens", usedToday)
+		if budget.PerDay > 0 {
+			fmt.Printf(" (%d left)", max(0, budget.PerDay-usedToday))
+		}
+		fmt.Println()
+		fmt.Printf("Cache:    %d responses\n\n", cached)
+
+		if len(days) == 0 {
+			fmt.Printf("No LLM usage in the last %d days.\n", daysBack)
+			return nil
+		}
+
+		var total database.LLMUsageDay
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "DAY\tREQUESTS\tCACHE HITS\tINPUT\tOUTPUT\tTOTAL")
+		for _, d := range days {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", d.Day, d.Requests, d.CacheHits, d.InputTokens, d.OutputTokens, d.InputTokens+d.OutputTokens)
+			total.Requests += d.Requests
+			total.CacheHits += d.CacheHits
+			total.InputTokens += d.InputTokens
+			total.OutputTokens += d.OutputTokens
+		}
+		_, _ = fmt.Fprintf(w, "total\t%d\t%d\t%d\t%d\t%d\n", total.Requests, total.CacheHits, total.InputTokens, total.OutputTokens, total.InputTokens+total.OutputTokens)
+		return w.Flush()
+	},
+}
+
+func tokenLimit(n int) string {
+	if n <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d tokens", n)
+}
+
+func init() {
+	rootCmd.AddCommand(llmCmd)
+	llmCmd.AddCommand(llmUsageCmd)
+
+	llmUsageCmd.Flags().Int("days", 7, "Number of days to report, including today")
+}
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
 	"github.com/Trungsherlock/jobgo/internal/skills"
 	"github.com/spf13/cobra"
 )
@@ -40,10 +43,16 @@ var profileShowCmd = &cobra.Command{
 		fmt.Printf("Email:              %s\n", p.Email)
 		fmt.Printf("Skills:             %s\n", p.Skills)
 		fmt.Printf("Experience (years): %d\n", p.ExperienceYears)
+		if p.ExperienceLevel != nil {
+			fmt.Printf("Experience Level:   %s\n", *p.ExperienceLevel)
+		}
 		fmt.Printf("Preferred Roles:    %s\n", p.PreferredRoles)
 		fmt.Printf("Preferred Locations:%s\n", p.PreferredLocations)
 		fmt.Printf("Min Match Score:    %.0f\n", p.MinMatchScore)
 		fmt.Printf("Visa Required:      %v\n", p.VisaRequired)
+		if p.ResumeRaw != "" {
+			fmt.Printf("Resume:             %d words\n", len(strings.Fields(p.ResumeRaw)))
+		}
 		return nil
 	},
 }
@@ -83,6 +92,26 @@ var profileSetCmd = &cobra.Command{
 		if cmd.Flags().Changed("experience") {
 			p.ExperienceYears, _ = cmd.Flags().GetInt("experience")
 		}
+		if cmd.Flags().Changed("level") {
+			level, _ := cmd.Flags().GetString("level")
+			level = strings.ToLower(strings.TrimSpace(level))
+			switch {
+			case level == "":
+				p.ExperienceLevel = nil
+			case slices.Contains(ranking.Levels, level):
+				p.ExperienceLevel = &level
+			default:
+				return fmt.Errorf("unknown level %q: use one of %s", level, strings.Join(ranking.Levels, ", "))
+			}
+		}
+		if cmd.Flags().Changed("resume") {
+			path, _ := cmd.Flags().GetString("resume")
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("reading resume: %w", err)
+			}
+			p.ResumeRaw = string(data)
+		}
 		if cmd.Flags().Changed("min-match") {
 			p.MinMatchScore, _ = cmd.Flags().GetFloat64("min-match")
 		}
@@ -96,6 +125,10 @@ var profileSetCmd = &cobra.Command{
 		}
 
 		fmt.Println("Profile updated.")
+		// Seniority and location fit depend on the profile.
+		if n, err := ranking.Update(db, *p); err == nil && n > 0 {
+			fmt.Printf("Re-ranked %d jobs.\n", n)
+		}
 		return nil
 	},
 }
@@ -137,8 +170,10 @@ func init() {
 	profileSetCmd.Flags().String("email", "", "Your email")
 	profileSetCmd.Flags().String("skills", "", "Comma-separated skills (Go,Docker,K8s)")
 	profileSetCmd.Flags().String("roles", "", "Comma-separated preferred roles")
-	profileSetCmd.Flags().String("locations", "", "Comma-separated preferred locations")
+	profileSetCmd.Flags().String("locations", "", "Comma-separated preferred locations (e.g. \"US,remote\" or \"NYC,London\")")
 	profileSetCmd.Flags().Int("experience", 0, "Years of experience")
+	profileSetCmd.Flags().String("level", "", "Experience level (intern, entry, mid, senior, staff, lead); derived from --experience when unset")
+	profileSetCmd.Flags().String("resume", "", "Plain-text resume file, used by the semantic matcher")
 	profileSetCmd.Flags().Float64("min-match", 50.0, "Minimum match score for notifications")
 	profileSetCmd.Flags().Bool("visa", false, "Require H1B visa sponsorship")
 }
\ No newline at end of file
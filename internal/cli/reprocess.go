@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/geo"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
+	"github.com/Trungsherlock/jobgo/internal/htmltext"
+	"github.com/Trungsherlock/jobgo/internal/matcher"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
+	"github.com/Trungsherlock/jobgo/internal/salary"
+	"github.com/Trungsherlock/jobgo/internal/scraper"
+	"github.com/Trungsherlock/jobgo/internal/skills"
+	"github.com/spf13/cobra"
+)
+
+var reprocessCmd = &cobra.Command{
+	Use:   "reprocess",
+	Short: "Re-run normalization, classification, skill, pay and location extraction, scoring and ranking over stored jobs",
+	Long: `Re-runs description normalization, the classifiers, skill, pay and
+location extraction, the scoring pipeline and the ranker over every stored job,
+open or closed, without scraping anything. Use it after upgrading jobgo to apply
+improved extraction to jobs you already have.
+
+Pay, places and the board's employment type label are re-derived from the
+payload each job was last scraped with, so fields the board sends outside the
+description are picked up too.
+
+With no flags, every step runs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		normalize, _ := cmd.Flags().GetBool("normalize")
+		classify, _ := cmd.Flags().GetBool("classify")
+		extract, _ := cmd.Flags().GetBool("skills")
+		places, _ := cmd.Flags().GetBool("places")
+		salaries, _ := cmd.Flags().GetBool("salary")
+		score, _ := cmd.Flags().GetBool("score")
+		rank, _ := cmd.Flags().GetBool("rank")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		profileName, _ := cmd.Flags().GetString("scoring-profile")
+		if !normalize && !classify && !extract && !places && !salaries && !score && !rank {
+			normalize, classify, extract, places, salaries, score, rank = true, true, true, true, true, true, true
+		}
+		if batchSize <= 0 {
+			batchSize = 200
+		}
+
+		var profile *database.Profile
+		var pipeline *matcher.Pipeline
+		if score || rank {
+			scoring, err := matcher.LoadScoringProfile(profileName)
+			if err != nil {
+				return err
+			}
+			p, err := db.GetProfile()
+			if err != nil || p == nil {
+				fmt.Println("No profile set; skipping scoring and ranking. Create one with: jobgo profile set")
+				score, rank = false, false
+			} else {
+				profile = p
+				if score {
+					pipeline = matcher.NewPipelineWithProfile(scoring)
+					if err := pipeline.Index(db); err != nil {
+						return err
+					}
+					pipeline.Track(db)
+				}
+			}
+		}
+
+		var decoders map[string]scraper.PayloadDecoder
+		if classify || places || salaries {
+			d, err := payloadDecoders()
+			if err != nil {
+				return err
+			}
+			decoders = d
+		}
+
+		total, err := db.CountJobs()
+		if err != nil {
+			return err
+		}
+		if total == 0 {
+			fmt.Println("No jobs stored.")
+			return nil
+		}
+
+		done, failed := 0, 0
+		for after := ""; ; {
+			batch, err := db.ListJobsAfter(after, batchSize)
+			if err != nil {
+				return fmt.Errorf("listing jobs: %w", err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			for _, job := range batch {
+				if err := reprocessJob(job, decoders[job.CompanyID], normalize, classify, extract, places, salaries, profile, pipeline); err != nil {
+					failed++
+				}
+			}
+			done += len(batch)
+			after = batch[len(batch)-1].ID
+			fmt.Printf("  %d/%d jobs reprocessed\n", done, total)
+		}
+
+		if pipeline != nil {
+			if usage, ok := pipeline.Usage(); ok {
+				fmt.Printf("  LLM: %s\n", usage)
+			}
+		}
+
+		// Ranking draws on classification and scores, so it runs last,
+		// over every job at once.
+		if rank {
+			ranked, err := ranking.Update(db, *profile)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("  %d jobs ranked\n", ranked)
+		}
+
+		fmt.Printf("Done. Reprocessed %d jobs", done)
+		if failed > 0 {
+			fmt.Printf(" (%d failed to save)", failed)
+		}
+		fmt.Println(".")
+		return nil
+	},
+}
+
+// payloadDecoders maps each company to the scraper that can decode the
+// payloads stored for its jobs. Companies on platforms without a decoder are
+// left out.
+func payloadDecoders() (map[string]scraper.PayloadDecoder, error) {
+	companies, err := db.ListCompanies()
+	if err != nil {
+		return nil, fmt.Errorf("listing companies: %w", err)
+	}
+	registry := scraper.NewRegistry()
+	decoders := make(map[string]scraper.PayloadDecoder)
+	for _, c := range companies {
+		s, err := registry.Get(c.Platform)
+		if err != nil {
+			continue
+		}
+		if d, ok := s.(scraper.PayloadDecoder); ok {
+			decoders[c.ID] = d
+		}
+	}
+	return decoders, nil
+}
+
+// reprocessJob runs the selected steps on one job. decoder, when non-nil,
+// rebuilds the posting from its stored payload. Scoring runs when pipeline
+// is non-nil.
+func reprocessJob(job database.Job, decoder scraper.PayloadDecoder, normalize, classify, extract, places, salaries bool, profile *database.Profile, pipeline *matcher.Pipeline) error {
+	description := ""
+	if job.Description != nil {
+		description = *job.Description
+	}
+
+	// Without a payload, the stored columns are all there is to go on.
+	posting := scraper.RawJob{Remote: job.Remote}
+	if job.Location != nil {
+		posting.Location = *job.Location
+	}
+	if job.PlatformEmploymentType != nil {
+		posting.EmploymentType = *job.PlatformEmploymentType
+	}
+	if decoder != nil && (classify || places || salaries) {
+		raw, err := db.GetJobPayload(job.ID)
+		if err != nil {
+			return err
+		}
+		if raw != nil {
+			if rj, err := decoder.DecodePayload(raw); err == nil {
+				posting.Compensation = rj.Compensation
+				posting.EmploymentType = rj.EmploymentType
+				posting.Remote = rj.Remote
+				// Some payloads only hold the detail page, which may
+				// leave the location to the listing it came from.
+				if rj.Location != "" {
+					posting.Location = rj.Location
+				}
+			}
+		}
+	}
+
+	if normalize && job.Description != nil {
+		// Jobs stored before normalization have no original; their
+		// description is it.
+		original := description
+		if job.OriginalDescription != nil {
+			original = *job.OriginalDescription
+		}
+		description = htmltext.ToText(original)
+		if err := db.UpdateJobDescription(job.ID, description, original); err != nil {
+			return err
+		}
+		job.Description = &description
+	}
+
+	if classify {
+		expLevel, isNewGrad, visaMentioned, visaSentiment := h1b.ClassifyJob(job)
+		if err := db.UpdateJobClassification(job.ID, expLevel, isNewGrad, visaMentioned, visaSentiment); err != nil {
+			return err
+		}
+		employmentType := h1b.ClassifyEmploymentType(posting.EmploymentType, job.Title, description)
+		if err := db.UpdateJobEmploymentType(job.ID, posting.EmploymentType, employmentType); err != nil {
+			return err
+		}
+		if err := db.UpdateJobRestrictions(job.ID, h1b.DetectRestrictions(job.Title, description)); err != nil {
+			return err
+		}
+	}
+
+	// Boards that haven't changed since an upgrade are never re-saved by a
+	// scrape, so this is how their existing jobs pick up salaries. A salary
+	// already stored is only replaced by one found again.
+	if salaries {
+		if r, ok := salary.Extract(posting.Compensation, description); ok {
+			if err := db.UpdateJobSalary(job.ID, &database.Salary{Min: r.Min, Max: r.Max, Currency: r.Currency, Period: r.Period}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if extract {
+		data, _ := json.Marshal(skills.ExtractFromJob(description))
+		if err := db.UpdateJobSkills(job.ID, string(data)); err != nil {
+			return err
+		}
+	}
+
+	if places {
+		if err := db.UpdateJobPlaces(job.ID, geo.Parse(posting.Location, posting.Remote)); err != nil {
+			return err
+		}
+	}
+
+	if pipeline != nil {
+		result := pipeline.Score(job, *profile)
+		if err := db.UpdateJobSkillScore(job.ID, result.Score, result.MatchedSkills, result.MissingSkills, result.Reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(reprocessCmd)
+
+	reprocessCmd.Flags().Bool("normalize", false, "Re-derive plain-text descriptions from the boards' original HTML")
+	reprocessCmd.Flags().Bool("classify", false, "Re-run experience, visa, employment type and restriction classification")
+	reprocessCmd.Flags().Bool("skills", false, "Re-extract required, preferred and mentioned skills")
+	reprocessCmd.Flags().Bool("places", false, "Re-parse job locations into places")
+	reprocessCmd.Flags().Bool("salary", false, "Re-extract stated pay from compensation fields and descriptions")
+	reprocessCmd.Flags().Bool("score", false, "Re-score jobs against your profile")
+	reprocessCmd.Flags().Bool("rank", false, "Recompute final scores from skill, seniority, location, H1B and freshness")
+	reprocessCmd.Flags().String("scoring-profile", "", "Scoring profile to weight skill sections with (default: scoring.profile from config)")
+	reprocessCmd.Flags().Int("batch-size", 200, "Jobs to load from the database at a time")
+}
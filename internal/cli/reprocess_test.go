@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/skills"
+)
+
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	d, err := database.New(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	if err := d.Migrate(filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	prev := db
+	db = d
+	t.Cleanup(func() {
+		db = prev
+		_ = d.Close()
+	})
+}
+
+func TestReprocessJobWritesSkills(t *testing.T) {
+	setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	_, _, _ = db.UpsertJob(database.JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer",
+		Description: "Requirements\n- Go\n- PostgreSQL\n\nNice to have\n- Kubernetes", URL: "https://example.com/1"})
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+
+	if err := reprocessJob(jobs[0], nil, false, false, true, false, false, nil, nil); err != nil {
+		t.Fatalf("reprocessJob: %v", err)
+	}
+
+	job, _ := db.GetJob(jobs[0].ID)
+	if job.Skills == nil {
+		t.Fatal("skills not written")
+	}
+	var got skills.JobSkills
+	if err := json.Unmarshal([]byte(*job.Skills), &got); err != nil {
+		t.Fatalf("stored skills %q: %v", *job.Skills, err)
+	}
+	if len(got.Required) == 0 {
+		t.Errorf("skills = %+v, want required skills extracted", got)
+	}
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/matcher"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
+	"github.com/spf13/cobra"
+)
+
+var scoreCmd = &cobra.Command{
+	Use:   "score",
+	Short: "Inspect how jobs are scored",
+}
+
+var scoreExplainCmd = &cobra.Command{
+	Use:   "explain <job-id>",
+	Short: "Show what each skill section contributed to a job's keyword score, and how the final score was reached",
+	Long: `Shows what each skill section contributed to a job's keyword score, and
+how the final score was reached.
+
+Only keyword scores break down by section. Under another matcher.type the
+explanation is of the keyword score, and the final score is reached from the
+job's stored skill score.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName, _ := cmd.Flags().GetString("scoring-profile")
+		scoring, err := matcher.LoadScoringProfile(profileName)
+		if err != nil {
+			return err
+		}
+
+		job, err := db.GetJob(args[0])
+		if err != nil {
+			return fmt.Errorf("getting job: %w", err)
+		}
+		profile, err := db.GetProfile()
+		if err != nil || profile == nil {
+			return fmt.Errorf("no profile set. Create one with: jobgo profile set")
+		}
+
+		e := matcher.NewSkillScorerWithProfile(scoring).Explain(*job, *profile)
+
+		// Rank on the score just explained so the two agree, unless jobs
+		// are scored some other way; then the stored score is the one that
+		// counts.
+		mode := matcher.ConfiguredMode()
+		keyword := mode == matcher.ModeKeyword
+		if !keyword {
+			fmt.Fprintf(os.Stderr, "Warning: matcher.type is %s; this explains the keyword score, not the %s score jobs are ranked on.\n", mode, mode)
+		}
+		ranked := *job
+		if keyword || job.SkillScore == nil {
+			ranked.SkillScore = &e.Result.Score
+		}
+		company, _ := db.GetCompany(job.CompanyID)
+		final, breakdown, _ := ranking.NewRanker(*profile, time.Now()).Rank(ranked, company)
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "json" {
+			data, _ := json.MarshalIndent(struct {
+				matcher.Explanation
+				FinalScore     float64                   `json:"final_score"`
+				ScoreBreakdown []database.ScoreComponent `json:"score_breakdown"`
+			}{e, final, breakdown}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("%s\n", job.Title)
+		fmt.Printf("Profile: %s\n\n", e.Profile)
+		if len(e.Sections) == 0 {
+			fmt.Printf("Score: %.1f (%s)\n", e.Result.Score, e.Result.Reason)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "SECTION\tWEIGHT\tMATCHED\tPOINTS\t")
+		for _, s := range e.Sections {
+			_, _ = fmt.Fprintf(w, "%s\t%g\t%d/%d\t%.1f\t%s\n", s.Section, s.Weight, len(s.Matched), s.Total, s.Points, s.Note)
+		}
+		_, _ = fmt.Fprintf(w, "total\t\t\t%.1f\t\n", e.Result.Score)
+		_ = w.Flush()
+
+		fmt.Println()
+		for _, s := range e.Sections {
+			if len(s.Matched) > 0 {
+				fmt.Printf("%-10s matched: %s\n", s.Section, strings.Join(s.Matched, ", "))
+			}
+			if len(s.Missing) > 0 {
+				fmt.Printf("%-10s missing: %s\n", s.Section, strings.Join(s.Missing, ", "))
+			}
+		}
+		fmt.Printf("\n%s\n", e.Result.Reason)
+
+		fmt.Printf("\nFinal score: %.1f\n\n", final)
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "COMPONENT\tSCORE\tWEIGHT\tPOINTS\t")
+		for _, c := range breakdown {
+			weight := "-"
+			if c.Weight > 0 {
+				weight = fmt.Sprintf("%.2f", c.Weight)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%.0f\t%s\t%+.1f\t%s\n", c.Name, c.Score, weight, c.Points, c.Detail)
+		}
+		_ = w.Flush()
+
+		if keyword && job.SkillScore != nil && fmt.Sprintf("%.1f", *job.SkillScore) != fmt.Sprintf("%.1f", e.Result.Score) {
+			fmt.Printf("\nStored skill score is %.1f; it was computed with a different profile or description.\n", *job.SkillScore)
+			fmt.Println("Re-score with: jobgo reprocess --score")
+		}
+		return nil
+	},
+}
+
+var scoreProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List the available scoring profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range matcher.ScoringProfiles() {
+			p, err := matcher.LoadScoringProfile(name)
+			if err != nil {
+				fmt.Printf("  %s: %v\n", name, err)
+				continue
+			}
+			fmt.Printf("  %s\n", p)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scoreCmd)
+	scoreCmd.AddCommand(scoreExplainCmd)
+	scoreCmd.AddCommand(scoreProfilesCmd)
+
+	scoreExplainCmd.Flags().String("scoring-profile", "", "Scoring profile to explain the score under (default: scoring.profile from config)")
+}
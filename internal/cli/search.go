@@ -4,13 +4,15 @@ import (
 	"context"
 	"fmt"
 	"time"
-	"os"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/dedup"
 	"github.com/Trungsherlock/jobgo/internal/matcher"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
 	"github.com/Trungsherlock/jobgo/internal/scraper"
 	"github.com/Trungsherlock/jobgo/internal/worker"
 	"github.com/Trungsherlock/jobgo/internal/h1b"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -22,6 +24,11 @@ var searchCmd = &cobra.Command{
 		timeout, _ := cmd.Flags().GetDuration("timeout")
 		platformFilter, _ := cmd.Flags().GetString("platform")
 		companyFilter, _ := cmd.Flags().GetString("company")
+		profileName, _ := cmd.Flags().GetString("scoring-profile")
+		scoring, err := matcher.LoadScoringProfile(profileName)
+		if err != nil {
+			return err
+		}
 
 		// Get companies to scrape
 		companies, err := db.ListCompanies()
@@ -65,13 +72,28 @@ var searchCmd = &cobra.Command{
 		pool := worker.NewPool(registry, db, 5)
 		results := pool.Run(ctx, filtered)
 
+		dupes, err := dedup.Assign(db)
+		if err != nil {
+			return fmt.Errorf("grouping duplicates: %w", err)
+		}
+
 		profile, err := db.GetProfile()
 		if err != nil {
 			return fmt.Errorf("getting profile: %w", err)
 		}
 
 		if profile != nil {
-			// Get all jobs without a match score
+			// Classify first: the H1B adjustment and ranking read it.
+			unclassified, _ := db.ListUnclassifiedJobs()
+			if len(unclassified) > 0 {
+				fmt.Printf("Classifying %d jobs...\n", len(unclassified))
+				for _, job := range unclassified {
+					expLevel, isNewGrad, visaMentioned, visaSentiment := h1b.ClassifyJob(job)
+					_ = db.UpdateJobClassification(job.ID, expLevel, isNewGrad, visaMentioned, visaSentiment)
+				}
+			}
+
+			// Get all jobs without a skill score
 			unscoredJobs, err := db.ListUnscoredJobs()
 			if err != nil {
 				return fmt.Errorf("listing unscored jobs: %w", err)
@@ -80,112 +102,132 @@ var searchCmd = &cobra.Command{
 			if len(unscoredJobs) > 0 {
 				fmt.Printf("\nScoring %d new jobs against profile...\n", len(unscoredJobs))
 				var m matcher.Matcher
-				apiKey := viper.GetString("anthropic_api_key")
-				if apiKey == "" {
-					apiKey = os.Getenv("ANTHROPIC_API_KEY")
-				}
+				provider, providerErr := matcher.LoadMatchProvider()
 
 				matcherType := viper.GetString("matcher")
 				switch matcherType {
 				case "llm":
-					if apiKey == "" {
-						fmt.Println("No API key found for LLM matcher. Set ANTHROPIC_API_KEY environment variable or use --matcher=keyword")
+					if providerErr != nil {
+						fmt.Printf("LLM matcher unavailable (%v); using keyword matching\n", providerErr)
 						m = matcher.NewKeywordMatcher()
 					} else {
-						m = matcher.NewLLMMatcher(apiKey)
+						m = matcher.NewLLMMatcher(provider)
 					}
 				case "hybrid":
-					if apiKey == "" {
-						fmt.Println("No API key found for Hybrid matcher. Set ANTHROPIC_API_KEY environment variable or use --matcher=keyword")
+					if providerErr != nil {
+						fmt.Printf("Hybrid matcher unavailable (%v); using keyword matching\n", providerErr)
 						m = matcher.NewKeywordMatcher()
 					} else {
 						threshold := viper.GetFloat64("hybrid_threshold")
 						if threshold == 0 {
 							threshold = 50.0
 						}
-						m = matcher.NewHybridMatcher(apiKey, threshold)
+						m = matcher.NewHybridMatcher(provider, threshold)
 					}
 				default:
 					m = matcher.NewKeywordMatcher()
 				}
-				scored := 0
+				pipeline := matcher.NewPipelineWithProfile(scoring)
+				if err := pipeline.Index(db); err != nil {
+					return err
+				}
+				// The match and skill scores share one run's LLM budget.
+				runID := uuid.New().String()
+				if t, ok := m.(matcher.Tracker); ok {
+					t.Track(db, runID, matcher.LoadBudget())
+				}
+				pipeline.TrackRun(db, runID)
+				// The H1B adjustment is applied to a fresh match score only,
+				// so re-running search never compounds it.
+				scored, adjusted := 0, 0
 				for _, job := range unscoredJobs {
 					result := m.Match(job, *profile)
-					_ = db.UpdateJobMatch(job.ID, result.Score, result.Reason)
-					scored++
-				}
-
-				if profile.VisaRequired {
-					allJobs, _ := db.ListJobs(0, "", false, false, false, false, false)
-					adjusted := 0
-					for _, job := range allJobs {
-						company, err := db.GetCompany(job.CompanyID)
-						if err != nil {
-							continue
-						}
-						adj := h1b.ScoreH1B(job, *company, *profile)
-						if adj.Delta != 0 {
-							newScore := 0.0
-							if job.MatchScore != nil {
-								newScore = *job.MatchScore
-							}
-							newScore += adj.Delta
-							if newScore < 0 {
-								newScore = 0
-							}
-							if newScore > 100 {
-								newScore = 100
+					if profile.VisaRequired {
+						if company, err := db.GetCompany(job.CompanyID); err == nil {
+							if adj := h1b.ScoreH1B(job, *company, *profile); adj.Delta != 0 {
+								result.Score = min(max(result.Score+adj.Delta, 0), 100)
+								if adj.Reason != "" {
+									result.Reason += " | " + adj.Reason
+								}
+								adjusted++
 							}
-							reason := ""
-							if job.MatchReason != nil {
-								reason = *job.MatchReason
-							}
-							if adj.Reason != "" {
-								reason += " | " + adj.Reason
-							}
-							_ = db.UpdateJobMatch(job.ID, newScore, reason)
-							adjusted++
 						}
 					}
-					if adjusted > 0 {
-						fmt.Printf("Applied H1B adjustments to %d jobs.\n", adjusted)
-					}
+					_ = db.UpdateJobMatch(job.ID, result.Score, result.Reason)
+					skill := pipeline.Score(job, *profile)
+					_ = db.UpdateJobSkillScore(job.ID, skill.Score, skill.MatchedSkills, skill.MissingSkills, skill.Reason)
+					scored++
+				}
+				if t, ok := m.(matcher.Tracker); ok {
+					fmt.Printf("LLM match: %s\n", t.Usage())
+				}
+				if usage, ok := pipeline.Usage(); ok {
+					fmt.Printf("LLM skills: %s\n", usage)
+				}
+				if adjusted > 0 {
+					fmt.Printf("Applied H1B adjustments to %d jobs.\n", adjusted)
 				}
 
 				fmt.Printf("Scored %d jobs.\n", scored)
 			}
-			unclassified, _ := db.ListUnclassifiedJobs()
-			if len(unclassified) > 0 {
-				fmt.Printf("Classifying %d jobs...\n", len(unclassified))
-				for _, job := range unclassified {
-					expLevel, isNewGrad, visaMentioned, visaSentiment := h1b.ClassifyJob(job)
-					_ = db.UpdateJobClassification(job.ID, expLevel, isNewGrad, visaMentioned, visaSentiment)
-				}
+			if _, err := ranking.Update(db, *profile); err != nil {
+				return fmt.Errorf("ranking jobs: %w", err)
 			}
 		}
 
-		var totalNew, failures int
+		var totalNew, failures, unchanged int
 		for _, r := range results {
-			if r.Err != nil {
+			switch {
+			case r.Err != nil:
 				failures++
 				fmt.Printf("  FAIL  %s: %v\n", r.Company.Name, r.Err)
-			} else {
-				fmt.Printf("  OK    %s: %d new jobs\n", r.Company.Name, r.JobCount)
+			case r.Unchanged:
+				unchanged++
+				fmt.Printf("  SAME  %s: unchanged since last scrape\n", r.Company.Name)
+			default:
+				fmt.Printf("  OK    %s: %d new jobs%s\n", r.Company.Name, r.JobCount, changeSummary(r))
 				totalNew += r.JobCount
 			}
 		}
 
-		fmt.Printf("\nDone. Found %d new jobs from %d companies. %d failed.\n",
-			totalNew, len(filtered)-failures, failures)
+		fmt.Printf("\nDone. Found %d new jobs from %d companies (%d unchanged). %d failed.\n",
+			totalNew, len(filtered)-failures, unchanged, failures)
+		if dupes > 0 {
+			fmt.Printf("%d postings are reposts or copies of another and are grouped under it.\n", dupes)
+		}
 
 		return nil
 	},
 }
 
+// changeSummary describes existing postings that changed, left or returned
+// to a board, and postings that failed to save, e.g.
+// ", 3 updated, 2 closed, 1 reopened, 1 failed to save".
+func changeSummary(r worker.Result) string {
+	var s string
+	if r.Revised > 0 {
+		s += fmt.Sprintf(", %d updated", r.Revised)
+	}
+	if r.Closed > 0 {
+		s += fmt.Sprintf(", %d closed", r.Closed)
+	}
+	if r.Reopened > 0 {
+		s += fmt.Sprintf(", %d reopened", r.Reopened)
+	}
+	if r.Failed > 0 {
+		s += fmt.Sprintf(", %d failed to save", r.Failed)
+	}
+	if r.Partial {
+		s += " (partial crawl, nothing closed)"
+	}
+	return s
+}
+
 func init() {
 	rootCmd.AddCommand(searchCmd)
 	
 	searchCmd.Flags().String("company", "", "Company name")
-	searchCmd.Flags().String("platform", "", "ATS platform (lever, greenhouse)")
+	searchCmd.Flags().String("platform", "", "ATS platform (lever, greenhouse, ashby, workday, smartrecruiters, workable, recruitee, jsonld, html, or an installed plugin)")
 	searchCmd.Flags().Duration("timeout", 30*time.Second, "Per-company scrape timeout")
+	searchCmd.Flags().String("scoring-profile", "", "Scoring profile to weight skill sections with (default: scoring.profile from config)")
 }
\ No newline at end of file
@@ -10,9 +10,13 @@ import (
 	"time"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/dedup"
 	"github.com/Trungsherlock/jobgo/internal/filter"
+	"github.com/Trungsherlock/jobgo/internal/freshness"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
 	"github.com/Trungsherlock/jobgo/internal/matcher"
 	"github.com/Trungsherlock/jobgo/internal/notifier"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
 	"github.com/Trungsherlock/jobgo/internal/scraper"
 	"github.com/Trungsherlock/jobgo/internal/worker"
 	"github.com/spf13/cobra"
@@ -26,6 +30,11 @@ var watchCmd = &cobra.Command{
 		interval, _ := cmd.Flags().GetDuration("interval")
 		minScore, _ := cmd.Flags().GetFloat64("min-score")
 		cartOnly, _ := cmd.Flags().GetBool("cart")
+		profileName, _ := cmd.Flags().GetString("scoring-profile")
+		scoring, err := matcher.LoadScoringProfile(profileName)
+		if err != nil {
+			return err
+		}
 
 		var notifiers []notifier.Notifier
 		notifiers = append(notifiers, notifier.NewTerminalNotifier())
@@ -63,7 +72,7 @@ var watchCmd = &cobra.Command{
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
-		runCycle(ctx, minScore, notifiers, cartOnly)
+		runCycle(ctx, minScore, notifiers, cartOnly, scoring)
 
 		for {
 			select {
@@ -71,13 +80,13 @@ var watchCmd = &cobra.Command{
 				fmt.Println("Watch stopped.")
 				return nil
 			case <-ticker.C:
-				runCycle(ctx, minScore, notifiers, cartOnly)
+				runCycle(ctx, minScore, notifiers, cartOnly, scoring)
 			}
 		}
 	},
 }
 
-func runCycle(ctx context.Context, minScore float64, notifiers []notifier.Notifier, cartOnly bool) {
+func runCycle(ctx context.Context, minScore float64, notifiers []notifier.Notifier, cartOnly bool, scoring matcher.ScoringProfile) {
 	if ctx.Err() != nil {
 		return
 	}
@@ -124,25 +133,56 @@ func runCycle(ctx context.Context, minScore float64, notifiers []notifier.Notifi
 	registry := scraper.NewRegistry()
 	pool := worker.NewPool(registry, db, 5)
 	results := pool.Run(ctx, enabled)
+	if _, err := dedup.Assign(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Error grouping duplicates: %v\n", err)
+	}
 
-	totalNew := 0
+	totalNew, unchanged, revised := 0, 0, 0
 	for _, r := range results {
-		if r.Err != nil {
+		switch {
+		case r.Err != nil:
 			fmt.Printf("  FAIL  %s: %v\n", r.Company.Name, r.Err)
-		} else {
+		case r.Unchanged:
+			unchanged++
+		default:
 			totalNew += r.JobCount
+			revised += r.Revised
 		}
 	}
+	if unchanged > 0 {
+		fmt.Printf("  %d boards unchanged since last scrape\n", unchanged)
+	}
+	if revised > 0 {
+		fmt.Printf("  %d existing jobs changed and will be re-scored\n", revised)
+	}
+
+	// Classify new jobs and revised ones, whose classification was cleared,
+	// before scoring: ranking and the notification filters read it.
+	unclassified, _ := db.ListUnclassifiedJobs()
+	for _, job := range unclassified {
+		expLevel, isNewGrad, visaMentioned, visaSentiment := h1b.ClassifyJob(job)
+		_ = db.UpdateJobClassification(job.ID, expLevel, isNewGrad, visaMentioned, visaSentiment)
+	}
 
 	// Score unscored jobs
 	profile, _ := db.GetProfile()
 	if profile != nil {
 		unscoredJobs, _ := db.ListUnscoredJobs()
-		pipeline := matcher.NewPipeline()
+		pipeline := matcher.NewPipelineWithProfile(scoring)
+		if err := pipeline.Index(db); err != nil {
+			fmt.Fprintf(os.Stderr, "Error indexing jobs: %v\n", err)
+		}
+		pipeline.Track(db)
 		for _, job := range unscoredJobs {
 			result := pipeline.Score(job, *profile)
 			_ = db.UpdateJobSkillScore(job.ID, result.Score, result.MatchedSkills, result.MissingSkills, result.Reason)
 		}
+		if usage, ok := pipeline.Usage(); ok && len(unscoredJobs) > 0 {
+			fmt.Printf("  LLM: %s\n", usage)
+		}
+		if _, err := ranking.Update(db, *profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error ranking jobs: %v\n", err)
+		}
 	}
 
 	// Print new high-match jobs
@@ -166,15 +206,19 @@ func runCycle(ctx context.Context, minScore float64, notifiers []notifier.Notifi
 				}
 			}
 			params.H1BOnly = true
+			params.ExcludeRestricted = true
 		}
 
 		filtered := filter.Apply(highMatches, filter.Build(params, sponsorIDs))
+		halfLife, now := freshness.HalfLife(), time.Now()
 
 		for _, j := range filtered {
-			score := 0.0
-			if j.SkillScore != nil {
-				score = *j.SkillScore
+			// Only the canonical posting of a group is announced; reposts
+			// and other locations' copies would repeat it.
+			if j.CanonicalID != nil {
+				continue
 			}
+			score, _ := ranking.Current(j, halfLife, now)
 			for _, n := range notifiers {
 				_ = n.Notify(j, j.CompanyName, score)
 			}
@@ -196,4 +240,5 @@ func init() {
 	watchCmd.Flags().Duration("interval", 30*time.Minute, "Polling interval between scrapes")
 	watchCmd.Flags().Float64("min-score", 50.0, "Minimum score to highlight")
 	watchCmd.Flags().Bool("cart", false, "Only watch companies in your job cart")
+	watchCmd.Flags().String("scoring-profile", "", "Scoring profile to weight skill sections with (default: scoring.profile from config)")
 }
@@ -22,14 +22,45 @@ func (d *DB) GetCompany(id string) (*Company, error) {
 	c := &Company{}
 	err := d.QueryRow(
 		`SELECT id, name, platform, slug, career_url, enabled, last_scraped_at, created_at,
-		h1b_sponsor_id, sponsors_h1b, h1b_approval_rate, h1b_total_filed, COALESCE(in_cart, 0), cart_added_at, last_notified_at FROM companies WHERE id = ?`, id,
-	).Scan(&c.ID, &c.Name, &c.Platform, &c.Slug, &c.CareerURL, &c.Enabled, NullableTime{&c.LastScrapedAt}, RequiredTime{&c.CreatedAt}, &c.H1bSponsorID, &c.SponsorsH1b, &c.H1bApprovalRate, &c.H1bTotalFiled, &c.InCart, NullableTime{&c.CartAddedAt}, NullableTime{&c.LastNotifiedAt})
+		h1b_sponsor_id, sponsors_h1b, h1b_approval_rate, h1b_total_filed, COALESCE(in_cart, 0), cart_added_at, last_notified_at,
+		verify_status, verify_error, verified_at, COALESCE(not_found_count, 0),
+		COALESCE(etag, ''), COALESCE(last_modified, ''), COALESCE(content_hash, '') FROM companies WHERE id = ?`, id,
+	).Scan(&c.ID, &c.Name, &c.Platform, &c.Slug, &c.CareerURL, &c.Enabled, NullableTime{&c.LastScrapedAt}, RequiredTime{&c.CreatedAt}, &c.H1bSponsorID, &c.SponsorsH1b, &c.H1bApprovalRate, &c.H1bTotalFiled, &c.InCart, NullableTime{&c.CartAddedAt}, NullableTime{&c.LastNotifiedAt},
+		&c.VerifyStatus, &c.VerifyError, NullableTime{&c.VerifiedAt}, &c.NotFoundCount,
+		&c.ETag, &c.LastModified, &c.ContentHash)
 	if err != nil {
 		return nil, fmt.Errorf("getting company: %w", err)
 	}
 	return c, nil
 }
 
+// FindCompany resolves a company by full ID or unique ID prefix, as shown by
+// `company list`.
+func (d *DB) FindCompany(idPrefix string) (*Company, error) {
+	rows, err := d.Query(`SELECT id FROM companies WHERE id LIKE ? LIMIT 2`, idPrefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("finding company: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("finding company: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("company not found: %s", idPrefix)
+	case 1:
+		return d.GetCompany(ids[0])
+	default:
+		return nil, fmt.Errorf("ambiguous prefix %s matched multiple companies", idPrefix)
+	}
+}
+
 func (d *DB) ListCompanies() ([]Company, error) {
 	return d.listCompaniesWhere("1=1 ORDER BY name")
 }
@@ -54,10 +85,48 @@ func (d *DB) UpdateCompanyLastScraped(id string) error {
 	return err
 }
 
+// UpdateCompanyBoardState stores the validators and content hash from the
+// company's latest successful scrape.
+func (d *DB) UpdateCompanyBoardState(id, etag, lastModified, contentHash string) error {
+	_, err := d.Exec(`UPDATE companies SET etag = ?, last_modified = ?, content_hash = ? WHERE id = ?`,
+		etag, lastModified, contentHash, id)
+	return err
+}
+
+// RecordCompanyVerification stores the outcome of `company verify` and returns
+// how many verifications in a row have come back not_found.
+func (d *DB) RecordCompanyVerification(id, status, errMsg string) (int, error) {
+	var verifyErr *string
+	if errMsg != "" {
+		verifyErr = &errMsg
+	}
+	_, err := d.Exec(
+		`UPDATE companies SET verify_status = ?, verify_error = ?, verified_at = CURRENT_TIMESTAMP,
+		not_found_count = CASE WHEN ? = 'not_found' THEN COALESCE(not_found_count, 0) + 1 ELSE 0 END
+		WHERE id = ?`,
+		status, verifyErr, status, id,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("recording verification: %w", err)
+	}
+	var n int
+	if err := d.QueryRow(`SELECT COALESCE(not_found_count, 0) FROM companies WHERE id = ?`, id).Scan(&n); err != nil {
+		return 0, fmt.Errorf("reading not-found count: %w", err)
+	}
+	return n, nil
+}
+
+func (d *DB) SetCompanyEnabled(id string, enabled bool) error {
+	_, err := d.Exec(`UPDATE companies SET enabled = ? WHERE id = ?`, enabled, id)
+	return err
+}
+
 func (d *DB) listCompaniesWhere(where string) ([]Company, error) {
 	rows, err := d.Query(`SELECT id, name, platform, slug, career_url, enabled, last_scraped_at, created_at,
         h1b_sponsor_id, sponsors_h1b, h1b_approval_rate, h1b_total_filed,
-        COALESCE(in_cart, 0), cart_added_at, last_notified_at
+        COALESCE(in_cart, 0), cart_added_at, last_notified_at,
+        verify_status, verify_error, verified_at, COALESCE(not_found_count, 0),
+        COALESCE(etag, ''), COALESCE(last_modified, ''), COALESCE(content_hash, '')
         FROM companies WHERE ` + where)
     if err != nil {
         return nil, err
@@ -70,7 +139,9 @@ func (d *DB) listCompaniesWhere(where string) ([]Company, error) {
 		if err := rows.Scan(&c.ID, &c.Name, &c.Platform, &c.Slug, &c.CareerURL, &c.Enabled,
 		NullableTime{&c.LastScrapedAt}, RequiredTime{&c.CreatedAt}, &c.H1bSponsorID, &c.SponsorsH1b,
 		&c.H1bApprovalRate, &c.H1bTotalFiled,
-		&c.InCart, NullableTime{&c.CartAddedAt}, NullableTime{&c.LastNotifiedAt}); err != nil {
+		&c.InCart, NullableTime{&c.CartAddedAt}, NullableTime{&c.LastNotifiedAt},
+		&c.VerifyStatus, &c.VerifyError, NullableTime{&c.VerifiedAt}, &c.NotFoundCount,
+		&c.ETag, &c.LastModified, &c.ContentHash); err != nil {
 			return nil, err
 		}
 		companies = append(companies, c)
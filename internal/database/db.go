@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -70,6 +71,25 @@ func (s RequiredTime) Scan(v interface{}) error {
 	return nil
 }
 
+// JSONColumn scans a nullable TEXT column holding JSON into V, leaving V
+// untouched for NULL.
+type JSONColumn struct{ V interface{} }
+
+func (s JSONColumn) Scan(v interface{}) error {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case string:
+		if x == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(x), s.V)
+	case []byte:
+		return json.Unmarshal(x, s.V)
+	}
+	return fmt.Errorf("unsupported JSON scan type %T", v)
+}
+
 type DB struct {
 	*sql.DB
 }
@@ -1,9 +1,13 @@
 package database
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 // helper: creates an in-memory DB with migrations applied
@@ -60,6 +64,35 @@ func TestCompanyCRUD(t *testing.T) {
 	}
 }
 
+func TestRecordCompanyVerification(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Stripe", "lever", "stripe", "")
+
+	for want := 1; want <= 2; want++ {
+		n, err := db.RecordCompanyVerification(c.ID, "not_found", "lever API returned status 404")
+		if err != nil {
+			t.Fatalf("RecordCompanyVerification: %v", err)
+		}
+		if n != want {
+			t.Errorf("not-found count = %d, want %d", n, want)
+		}
+	}
+
+	n, _ := db.RecordCompanyVerification(c.ID, "ok", "")
+	if n != 0 {
+		t.Errorf("not-found count after ok = %d, want 0", n)
+	}
+
+	got, err := db.FindCompany(c.ID[:8])
+	if err != nil {
+		t.Fatalf("FindCompany: %v", err)
+	}
+	if got.VerifyStatus == nil || *got.VerifyStatus != "ok" || got.VerifyError != nil || got.VerifiedAt == nil {
+		t.Errorf("verification not recorded: status=%v err=%v at=%v", got.VerifyStatus, got.VerifyError, got.VerifiedAt)
+	}
+}
+
 func TestJobCRUD(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -99,6 +132,323 @@ func TestJobCRUD(t *testing.T) {
 	}
 }
 
+func TestSyncOpenJobs(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	for _, ext := range []string{"a", "b", "c"} {
+		if _, err := db.CreateJob(c.ID, ext, "Engineer "+ext, "", "", "", "", "https://example.com/"+ext, false, nil); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+	}
+
+	closed, reopened, err := db.SyncOpenJobs(c.ID, []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("SyncOpenJobs: %v", err)
+	}
+	if closed != 1 || reopened != 0 {
+		t.Errorf("closed=%d reopened=%d, want 1/0", closed, reopened)
+	}
+
+	open, _ := db.ListJobs(0, "", false, false, false, false, false)
+	if len(open) != 2 {
+		t.Errorf("got %d open jobs, want 2", len(open))
+	}
+	all, _ := db.QueryJobs(JobQuery{IncludeClosed: true})
+	if len(all) != 3 {
+		t.Fatalf("got %d jobs including closed, want 3", len(all))
+	}
+	for _, j := range all {
+		if (*j.ExternalID == "c") != (j.ClosedAt != nil) {
+			t.Errorf("job %s closed_at=%v", *j.ExternalID, j.ClosedAt)
+		}
+	}
+
+	closed, reopened, _ = db.SyncOpenJobs(c.ID, []string{"a", "b", "c"})
+	if closed != 0 || reopened != 1 {
+		t.Errorf("closed=%d reopened=%d after reappearing, want 0/1", closed, reopened)
+	}
+	open, _ = db.ListJobs(0, "", false, false, false, false, false)
+	if len(open) != 3 {
+		t.Errorf("got %d open jobs after reopen, want 3", len(open))
+	}
+}
+
+func TestUpsertJobRecordsRevisions(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	in := JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", Description: "Go", Location: "Remote", URL: "https://example.com/1"}
+
+	created, revised, err := db.UpsertJob(in)
+	if err != nil || !created || revised {
+		t.Fatalf("first UpsertJob = %v, %v, %v; want created", created, revised, err)
+	}
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+	id := jobs[0].ID
+	_ = db.UpdateJobSkillScore(id, 80, []string{"go"}, nil, "good")
+	_ = db.UpdateJobClassification(id, "mid", false, false, "")
+
+	created, revised, _ = db.UpsertJob(in)
+	if created || revised {
+		t.Errorf("identical re-scrape = created %v, revised %v; want neither", created, revised)
+	}
+
+	in.Title = "Senior Backend Engineer"
+	in.Description = "Go and Kubernetes"
+	created, revised, err = db.UpsertJob(in)
+	if err != nil || created || !revised {
+		t.Fatalf("changed UpsertJob = %v, %v, %v; want revised", created, revised, err)
+	}
+
+	job, _ := db.GetJob(id)
+	if job.Title != "Senior Backend Engineer" || job.SkillScore != nil || job.ExperienceLevel != nil {
+		t.Errorf("job after change: title=%q score=%v level=%v; want new title and cleared scores", job.Title, job.SkillScore, job.ExperienceLevel)
+	}
+
+	revs, err := db.ListJobRevisions(id)
+	if err != nil {
+		t.Fatalf("ListJobRevisions: %v", err)
+	}
+	if len(revs) != 1 || revs[0].Title != "Backend Engineer" || revs[0].Description != "Go" {
+		t.Errorf("revisions = %+v, want the original version", revs)
+	}
+}
+
+func TestUpsertJobNormalizesStoredHTML(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "greenhouse", "testco", "")
+	// Stored before descriptions were normalized: the HTML is the description.
+	legacy := JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", Description: "<p>Go</p>", URL: "https://example.com/1"}
+	_, _, _ = db.UpsertJob(legacy)
+
+	in := legacy
+	in.Description, in.OriginalDescription = "Go", "<p>Go</p>"
+	created, revised, err := db.UpsertJob(in)
+	if err != nil || created || revised {
+		t.Fatalf("UpsertJob = %v, %v, %v; want the same posting converted in place", created, revised, err)
+	}
+
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+	if j := jobs[0]; deref(j.Description) != "Go" || deref(j.OriginalDescription) != "<p>Go</p>" {
+		t.Errorf("description = %q, original = %q", deref(j.Description), deref(j.OriginalDescription))
+	}
+	if revs, _ := db.ListJobRevisions(jobs[0].ID); len(revs) != 0 {
+		t.Errorf("revisions = %+v, want none", revs)
+	}
+}
+
+func TestUpsertJobIgnoresMarkupChanges(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	// Stored when Lever postings were scraped as plain text.
+	legacy := JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", Description: "We build payments.\nJoin us.\n\nRequirements\n<li>Go</li><li>SQL</li>", URL: "https://example.com/1"}
+	_, _, _ = db.UpsertJob(legacy)
+
+	in := legacy
+	in.OriginalDescription = "<div>We build payments. Join us.</div><h3>Requirements</h3><ul><li>Go</li><li>SQL</li></ul>"
+	in.Description = "We build payments. Join us.\n\nRequirements\n- Go\n- SQL"
+	created, revised, err := db.UpsertJob(in)
+	if err != nil || created || revised {
+		t.Fatalf("UpsertJob = %v, %v, %v; want the posting kept without a revision", created, revised, err)
+	}
+
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+	if j := jobs[0]; deref(j.Description) != in.Description || deref(j.OriginalDescription) != in.OriginalDescription {
+		t.Errorf("description = %q, original = %q; want the new form stored", deref(j.Description), deref(j.OriginalDescription))
+	}
+
+	in.Description = "We build payments. Join us.\n\nRequirements\n- Go\n- Rust"
+	if _, revised, _ := db.UpsertJob(in); !revised {
+		t.Error("changed requirements were not recorded as a revision")
+	}
+}
+
+func TestUpsertJobKeepsDescriptionWhenMissing(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "smartrecruiters", "testco", "")
+	in := JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", URL: "https://example.com/1",
+		Description: "We build payments.", OriginalDescription: "<p>We build payments.</p>"}
+	_, _, _ = db.UpsertJob(in)
+
+	// The posting's detail failed to load, so it arrives without a description.
+	in.Description, in.OriginalDescription = "", ""
+	if _, revised, err := db.UpsertJob(in); err != nil || revised {
+		t.Fatalf("UpsertJob without description: revised = %v, err = %v; want an unchanged job", revised, err)
+	}
+
+	// A retitled posting still keeps the description it had.
+	in.Title = "Senior Backend Engineer"
+	if _, revised, _ := db.UpsertJob(in); !revised {
+		t.Fatal("retitled job was not recorded as a revision")
+	}
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+	j, _ := db.GetJob(jobs[0].ID)
+	if deref(j.Description) != "We build payments." || deref(j.OriginalDescription) != "<p>We build payments.</p>" {
+		t.Errorf("description = %q, original = %q; want the stored ones kept", deref(j.Description), deref(j.OriginalDescription))
+	}
+}
+
+func TestUpsertJobKeepsFirstSeen(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "greenhouse", "testco", "")
+	in := JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", URL: "https://example.com/1"}
+	_, _, _ = db.UpsertJob(in)
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+	firstSeen := jobs[0].FirstSeenAt
+	if firstSeen == nil {
+		t.Fatal("first_seen_at not set on insert")
+	}
+
+	// The board edits the posting: its update time moves, first-seen does not.
+	updated := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	in.UpdatedAt = &updated
+	if _, _, err := db.UpsertJob(in); err != nil {
+		t.Fatalf("UpsertJob: %v", err)
+	}
+	j, _ := db.GetJob(jobs[0].ID)
+	if j.PlatformUpdatedAt == nil || !j.PlatformUpdatedAt.Equal(updated) {
+		t.Errorf("platform_updated_at = %v, want %v", j.PlatformUpdatedAt, updated)
+	}
+	if j.PostedAt != nil {
+		t.Errorf("posted_at = %v, want unset", j.PostedAt)
+	}
+	if j.FirstSeenAt == nil || !j.FirstSeenAt.Equal(*firstSeen) {
+		t.Errorf("first_seen_at = %v, want %v", j.FirstSeenAt, firstSeen)
+	}
+}
+
+func TestJobPayloadAndBatches(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	// Stored before payloads were kept.
+	_, _, _ = db.UpsertJob(JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", URL: "https://example.com/1"})
+	for _, ext := range []string{"ext-2", "ext-3"} {
+		_, _, _ = db.UpsertJob(JobInput{CompanyID: c.ID, ExternalID: ext, Title: "Engineer " + ext, URL: "https://example.com/" + ext})
+	}
+
+	raw := json.RawMessage(`{"id":"ext-1","categories":{"commitment":"Intern"}}`)
+	_, _, _ = db.UpsertJob(JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", URL: "https://example.com/1", RawPayload: raw})
+
+	var seen []Job
+	for after := ""; ; {
+		batch, err := db.ListJobsAfter(after, 2)
+		if err != nil {
+			t.Fatalf("ListJobsAfter: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		seen = append(seen, batch...)
+		after = batch[len(batch)-1].ID
+	}
+	// An unchanged posting still refreshes its payload.
+	raw = json.RawMessage(`{"id":"ext-1","categories":{"commitment":"Full-time"}}`)
+	_, _, _ = db.UpsertJob(JobInput{CompanyID: c.ID, ExternalID: "ext-1", Title: "Backend Engineer", URL: "https://example.com/1", RawPayload: raw})
+
+	if n, _ := db.CountJobs(); len(seen) != 3 || n != 3 {
+		t.Fatalf("walked %d jobs, CountJobs = %d; want 3", len(seen), n)
+	}
+
+	for _, j := range seen {
+		got, err := db.GetJobPayload(j.ID)
+		if err != nil {
+			t.Fatalf("GetJobPayload: %v", err)
+		}
+		if *j.ExternalID == "ext-1" && string(got) != string(raw) {
+			t.Errorf("payload = %s, want the latest scrape's", got)
+		}
+		if *j.ExternalID != "ext-1" && got != nil {
+			t.Errorf("payload for %s = %s, want none", *j.ExternalID, got)
+		}
+	}
+}
+
+func TestQueryJobsMinScoreUsesFinalScore(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	for _, ext := range []string{"ranked-high", "ranked-low", "unranked"} {
+		_, _, _ = db.UpsertJob(JobInput{CompanyID: c.ID, ExternalID: ext, Title: ext, URL: "https://example.com/" + ext})
+	}
+	jobs, _ := db.ListJobs(0, "", false, false, false, false, false)
+	for _, j := range jobs {
+		switch *j.ExternalID {
+		case "ranked-high":
+			_ = db.UpdateJobSkillScore(j.ID, 40, nil, nil, "")
+			_ = db.UpdateJobFinalScore(j.ID, 75, nil)
+		case "ranked-low":
+			_ = db.UpdateJobSkillScore(j.ID, 90, nil, nil, "")
+			_ = db.UpdateJobFinalScore(j.ID, 55, nil)
+		case "unranked":
+			_ = db.UpdateJobSkillScore(j.ID, 70, nil, nil, "")
+		}
+	}
+
+	got, err := db.QueryJobs(JobQuery{MinScore: 60})
+	if err != nil {
+		t.Fatalf("QueryJobs: %v", err)
+	}
+	var ids []string
+	for _, j := range got {
+		ids = append(ids, *j.ExternalID)
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != "ranked-high,unranked" {
+		t.Errorf("jobs with score >= 60 = %v, want ranked-high and unranked", ids)
+	}
+}
+
+func TestQueryJobsMinSalary(t *testing.T) {
+	db := setupTestDB(t)
+
+	c, _ := db.CreateCompany("Test Co", "lever", "testco", "")
+	inputs := []JobInput{
+		{ExternalID: "annual", Salary: &Salary{Min: 140000, Max: 180000, Currency: "USD", Period: "year"}},
+		{ExternalID: "hourly", Salary: &Salary{Min: 60, Max: 80, Currency: "USD", Period: "hour"}},
+		{ExternalID: "low", Salary: &Salary{Min: 90000, Max: 110000, Currency: "USD", Period: "year"}},
+		{ExternalID: "euro", Salary: &Salary{Min: 150000, Max: 170000, Currency: "EUR", Period: "year"}},
+		{ExternalID: "unstated"},
+	}
+	for _, in := range inputs {
+		in.CompanyID, in.Title, in.URL = c.ID, "Engineer "+in.ExternalID, "https://example.com/"+in.ExternalID
+		if _, _, err := db.UpsertJob(in); err != nil {
+			t.Fatalf("UpsertJob: %v", err)
+		}
+	}
+
+	jobs, err := db.QueryJobs(JobQuery{MinSalary: 150000})
+	if err != nil {
+		t.Fatalf("QueryJobs: %v", err)
+	}
+	got := map[string]bool{}
+	for _, j := range jobs {
+		got[*j.ExternalID] = true
+	}
+	if len(got) != 2 || !got["annual"] || !got["hourly"] {
+		t.Errorf("jobs paying 150000+/yr = %v, want annual and hourly", got)
+	}
+	if jobs, _ := db.QueryJobs(JobQuery{MinSalary: 150000, SalaryCurrency: "eur"}); len(jobs) != 1 || *jobs[0].ExternalID != "euro" {
+		t.Errorf("jobs paying EUR 150000+/yr = %d, want only the euro job", len(jobs))
+	}
+
+	// A salary found on a later scrape fills in an unchanged job.
+	in := JobInput{CompanyID: c.ID, ExternalID: "unstated", Title: "Engineer unstated", URL: "https://example.com/unstated",
+		Salary: &Salary{Min: 200000, Max: 200000, Currency: "USD", Period: "year"}}
+	if _, revised, _ := db.UpsertJob(in); revised {
+		t.Error("adding a salary alone should not count as a revision")
+	}
+	jobs, _ = db.QueryJobs(JobQuery{MinSalary: 150000})
+	if len(jobs) != 3 {
+		t.Errorf("got %d jobs after backfill, want 3", len(jobs))
+	}
+}
+
 func TestProfileUpsert(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -139,3 +489,58 @@ func TestProfileUpsert(t *testing.T) {
 		t.Errorf("got name=%s, want John Updated", p.Name)
 	}
 }
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestLLMCacheAndUsage(t *testing.T) {
+	db := setupTestDB(t)
+
+	if _, ok, err := db.GetLLMCache("k"); err != nil || ok {
+		t.Fatalf("empty cache: ok=%v err=%v", ok, err)
+	}
+	if err := db.PutLLMCache("k", "ollama", "llama3.1", `{"score":1}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.PutLLMCache("k", "ollama", "llama3.1", `{"score":2}`); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, _ := db.GetLLMCache("k"); !ok || got != `{"score":2}` {
+		t.Errorf("cached = %q, want the newer response", got)
+	}
+
+	for _, u := range []LLMUsage{
+		{RunID: "r", Provider: "ollama", Model: "llama3.1", InputTokens: 300, OutputTokens: 50},
+		{RunID: "r", Provider: "ollama", Model: "llama3.1", InputTokens: 200, OutputTokens: 50},
+		{RunID: "r", Provider: "ollama", Model: "llama3.1", Cached: true},
+	} {
+		if err := db.RecordLLMUsage(u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hourAgo := time.Now().Add(-time.Hour)
+	if n, _ := db.LLMTokensSince(hourAgo); n != 600 {
+		t.Errorf("tokens = %d, want 600", n)
+	}
+	if n, _ := db.LLMTokensSince(time.Now().Add(time.Hour)); n != 0 {
+		t.Errorf("tokens in the future = %d, want 0", n)
+	}
+	if n, _ := db.LLMTokensForRun("r"); n != 600 {
+		t.Errorf("run tokens = %d, want 600", n)
+	}
+	if n, _ := db.LLMTokensForRun("other"); n != 0 {
+		t.Errorf("other run tokens = %d, want 0", n)
+	}
+	days, err := db.LLMUsageByDay(time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 1 || days[0].Requests != 2 || days[0].CacheHits != 1 || days[0].InputTokens != 500 {
+		t.Errorf("days = %+v", days)
+	}
+}
@@ -1,10 +1,13 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 	"encoding/json"
 
+	"github.com/Trungsherlock/jobgo/internal/htmltext"
 	"github.com/google/uuid"
 )
 
@@ -22,59 +25,452 @@ func (d *DB) CreateJob(companyID, externalID, title, description, location, depa
 	return n > 0, nil
 }
 
+// JobInput is a scraped posting to store with UpsertJob.
+type JobInput struct {
+	CompanyID   string
+	ExternalID  string
+	Title       string
+	// Description is the normalized plain-text description.
+	Description string
+	Location    string
+	Department  string
+	Skills      string
+	URL         string
+	Remote      bool
+	PostedAt    *time.Time
+	// UpdatedAt is when the board says the posting was last edited.
+	UpdatedAt *time.Time
+	// Salary is the extracted pay range, if the posting states one.
+	Salary *Salary
+	// Places is the parsed location.
+	Places []Place
+	// OriginalDescription is the description as the board served it.
+	OriginalDescription string
+	// PlatformEmploymentType is the board's own label, if it has one.
+	PlatformEmploymentType string
+	// EmploymentType is the classified type; empty leaves it unset.
+	EmploymentType string
+	// Restrictions are the eligibility requirements found in the posting.
+	Restrictions []Restriction
+	// RawPayload is the platform's own JSON for the posting.
+	RawPayload json.RawMessage
+}
+
+// Salary is a pay range as stored on a job.
+type Salary struct {
+	Min      float64
+	Max      float64
+	Currency string
+	Period   string
+}
+
+func (s *Salary) columns() (min, max, currency, period interface{}) {
+	if s == nil {
+		return nil, nil, nil, nil
+	}
+	return s.Min, s.Max, s.Currency, s.Period
+}
+
+// nullString stores an empty string as NULL.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// placesColumn encodes parsed places for storage; nil stays NULL so the
+// job is parsed again later.
+func placesColumn(places []Place) interface{} {
+	if places == nil {
+		return nil
+	}
+	data, _ := json.Marshal(places)
+	return string(data)
+}
+
+// restrictionsColumn encodes detected restrictions the same way.
+func restrictionsColumn(restrictions []Restriction) interface{} {
+	if restrictions == nil {
+		return nil
+	}
+	data, _ := json.Marshal(restrictions)
+	return string(data)
+}
+
+// UpsertJob inserts a new posting, or refreshes an existing one when its
+// title, description, location or department changed. A changed job keeps
+// the previous content as a revision and has its scores and classification
+// cleared so the next run re-scores it.
+func (d *DB) UpsertJob(in JobInput) (created, revised bool, err error) {
+	tx, err := d.Begin()
+	if err != nil {
+		return false, false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	salaryMin, salaryMax, salaryCurrency, salaryPeriod := in.Salary.columns()
+	places := placesColumn(in.Places)
+	platformType, employmentType := nullString(in.PlatformEmploymentType), nullString(in.EmploymentType)
+	restrictions := restrictionsColumn(in.Restrictions)
+	original := nullString(in.OriginalDescription)
+	var rawPayload interface{}
+	if len(in.RawPayload) > 0 {
+		rawPayload = string(in.RawPayload)
+	}
+
+	var old JobRevision
+	var hasSalary, hasPlaces, hasType, hasRestrictions, hasOriginal bool
+	err = tx.QueryRow(
+		`SELECT id, title, COALESCE(description, ''), COALESCE(location, ''), COALESCE(department, ''), salary_max IS NOT NULL, places IS NOT NULL, employment_type IS NOT NULL, restrictions IS NOT NULL,
+		 description_original IS NOT NULL
+		 FROM jobs WHERE company_id = ? AND external_id = ?`, in.CompanyID, in.ExternalID,
+	).Scan(&old.JobID, &old.Title, &old.Description, &old.Location, &old.Department, &hasSalary, &hasPlaces, &hasType, &hasRestrictions, &hasOriginal)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(
+			`INSERT INTO jobs (id, company_id, external_id, title, description, location, remote, department, skills, url, posted_at, scraped_at,
+			 salary_min, salary_max, salary_currency, salary_period, places, platform_employment_type, employment_type, restrictions, raw_payload, description_original,
+			 platform_updated_at, first_seen_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+			uuid.New().String(), in.CompanyID, in.ExternalID, in.Title, in.Description, in.Location, in.Remote, in.Department, in.Skills, in.URL, in.PostedAt,
+			salaryMin, salaryMax, salaryCurrency, salaryPeriod, places, platformType, employmentType, restrictions, rawPayload, original,
+			in.UpdatedAt,
+		)
+		if err != nil {
+			return false, false, fmt.Errorf("inserting job: %w", err)
+		}
+		return true, false, tx.Commit()
+	case err != nil:
+		return false, false, fmt.Errorf("looking up job: %w", err)
+	}
+
+	// A board that failed to send a posting's description (a detail page
+	// that would not load) has not emptied it; keep what is stored.
+	if in.Description == "" {
+		in.Description = old.Description
+	}
+
+	// Jobs stored before descriptions were normalized hold the board's
+	// original text, and boards that moved from plain text to HTML send the
+	// same posting in a new form; neither is a change to the posting.
+	sameDescription := old.Description == in.Description || sameText(old.Description, in.Description)
+
+	if old.Title == in.Title && sameDescription && old.Location == in.Location && old.Department == in.Department {
+		// Jobs stored before pay, places, employment type or restrictions
+		// were kept pick them up on the next scrape. The raw payload is
+		// always replaced, so reprocess reads what the board last sent.
+		var sets []string
+		var args []interface{}
+		if !hasSalary && in.Salary != nil {
+			sets = append(sets, "salary_min = ?, salary_max = ?, salary_currency = ?, salary_period = ?")
+			args = append(args, salaryMin, salaryMax, salaryCurrency, salaryPeriod)
+		}
+		if !hasPlaces && places != nil {
+			sets = append(sets, "places = ?")
+			args = append(args, places)
+		}
+		if !hasType && employmentType != nil {
+			sets = append(sets, "platform_employment_type = ?, employment_type = ?")
+			args = append(args, platformType, employmentType)
+		}
+		if !hasRestrictions && restrictions != nil {
+			sets = append(sets, "restrictions = ?")
+			args = append(args, restrictions)
+		}
+		if original != nil && (!hasOriginal || old.Description != in.Description) {
+			sets = append(sets, "description = ?, description_original = ?")
+			args = append(args, in.Description, original)
+		}
+		if rawPayload != nil {
+			sets = append(sets, "raw_payload = ?")
+			args = append(args, rawPayload)
+		}
+		// Boards move their own dates without changing the content.
+		_, err = tx.Exec(
+			`UPDATE jobs SET posted_at = ?, platform_updated_at = ?
+			 WHERE id = ? AND (posted_at IS NOT ? OR platform_updated_at IS NOT ?)`,
+			in.PostedAt, in.UpdatedAt, old.JobID, in.PostedAt, in.UpdatedAt,
+		)
+		if err != nil {
+			return false, false, fmt.Errorf("updating job dates: %w", err)
+		}
+		if len(sets) > 0 {
+			_, err = tx.Exec(`UPDATE jobs SET `+strings.Join(sets, ", ")+` WHERE id = ?`, append(args, old.JobID)...)
+			if err != nil {
+				return false, false, fmt.Errorf("updating job: %w", err)
+			}
+		}
+		return false, false, tx.Commit()
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO job_revisions (job_id, title, description, location, department) VALUES (?, ?, ?, ?, ?)`,
+		old.JobID, old.Title, old.Description, old.Location, old.Department,
+	)
+	if err != nil {
+		return false, false, fmt.Errorf("saving job revision: %w", err)
+	}
+	_, err = tx.Exec(
+		`UPDATE jobs SET title = ?, description = ?, location = ?, department = ?, url = ?, remote = ?, posted_at = ?, scraped_at = CURRENT_TIMESTAMP,
+		 salary_min = ?, salary_max = ?, salary_currency = ?, salary_period = ?, places = ?,
+		 platform_employment_type = ?, employment_type = ?, restrictions = ?, raw_payload = COALESCE(?, raw_payload),
+		 description_original = COALESCE(?, description_original), platform_updated_at = ?,
+		 match_score = NULL, match_reason = NULL, skill_score = NULL, skill_matched = NULL, skill_missing = NULL, skill_reason = NULL, skill_scored_at = NULL, final_score = NULL, score_breakdown = NULL,
+		 experience_level = NULL
+		 WHERE id = ?`,
+		in.Title, in.Description, in.Location, in.Department, in.URL, in.Remote, in.PostedAt,
+		salaryMin, salaryMax, salaryCurrency, salaryPeriod, places,
+		platformType, employmentType, restrictions, rawPayload, original, in.UpdatedAt, old.JobID,
+	)
+	if err != nil {
+		return false, false, fmt.Errorf("updating job: %w", err)
+	}
+	return false, true, tx.Commit()
+}
+
+// sameText reports whether two descriptions read the same once markup,
+// whitespace and list markers are set aside.
+func sameText(a, b string) bool {
+	return textWords(a) == textWords(b)
+}
+
+func textWords(s string) string {
+	var words []string
+	for _, w := range strings.Fields(htmltext.ToText(s)) {
+		switch w {
+		case "-", "*", "•", "·":
+			continue
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " ")
+}
+
+// ListJobRevisions returns a job's earlier versions, oldest first. The
+// current version is the job itself.
+func (d *DB) ListJobRevisions(jobID string) ([]JobRevision, error) {
+	rows, err := d.Query(
+		`SELECT id, job_id, title, COALESCE(description, ''), COALESCE(location, ''), COALESCE(department, ''), captured_at
+		 FROM job_revisions WHERE job_id = ? ORDER BY id`, jobID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing job revisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	revs := make([]JobRevision, 0)
+	for rows.Next() {
+		var r JobRevision
+		if err := rows.Scan(&r.ID, &r.JobID, &r.Title, &r.Description, &r.Location, &r.Department, RequiredTime{&r.CapturedAt}); err != nil {
+			return nil, fmt.Errorf("scanning job revision: %w", err)
+		}
+		revs = append(revs, r)
+	}
+	return revs, rows.Err()
+}
+
 func (d *DB) GetJob(id string) (*Job, error) {
 	j := &Job{}
 	err := d.QueryRow(
-		`SELECT j.id, j.company_id, COALESCE(c.name, '') as company_name, j.external_id, j.title, j.description, j.location, j.remote, j.department, j.skills, j.url, j.posted_at, j.scraped_at, j.match_score, j.match_reason, j.status, j.created_at, j.experience_level, j.visa_mentioned, j.visa_sentiment, j.is_new_grad, j.skill_score, j.skill_matched, j.skill_missing, j.skill_reason, j.skill_scored_at
+		`SELECT j.id, j.company_id, COALESCE(c.name, '') as company_name, j.external_id, j.title, j.description, j.location, j.remote, j.department, j.skills, j.url, j.posted_at, j.scraped_at, j.match_score, j.match_reason, j.status, j.created_at, j.experience_level, j.visa_mentioned, j.visa_sentiment, j.is_new_grad, j.skill_score, j.skill_matched, j.skill_missing, j.skill_reason, j.skill_scored_at, j.closed_at, j.canonical_id, j.salary_min, j.salary_max, j.salary_currency, j.salary_period, j.places, j.platform_employment_type, j.employment_type, j.restrictions, j.description_original, j.platform_updated_at, j.first_seen_at, j.final_score, j.score_breakdown
 		 FROM jobs j LEFT JOIN companies c ON j.company_id = c.id WHERE j.id = ?`, id,
-	).Scan(&j.ID, &j.CompanyID, &j.CompanyName, &j.ExternalID, &j.Title, &j.Description, &j.Location, &j.Remote, &j.Department, &j.Skills, &j.URL, NullableTime{&j.PostedAt}, NullableTime{&j.ScrapedAt}, &j.MatchScore, &j.MatchReason, &j.Status, RequiredTime{&j.CreatedAt}, &j.ExperienceLevel, &j.VisaMentioned, &j.VisaSentiment, &j.IsNewGrad, &j.SkillScore, &j.SkillMatched, &j.SkillMissing, &j.SkillReason, NullableTime{&j.SkillScoredAt})
+	).Scan(&j.ID, &j.CompanyID, &j.CompanyName, &j.ExternalID, &j.Title, &j.Description, &j.Location, &j.Remote, &j.Department, &j.Skills, &j.URL, NullableTime{&j.PostedAt}, NullableTime{&j.ScrapedAt}, &j.MatchScore, &j.MatchReason, &j.Status, RequiredTime{&j.CreatedAt}, &j.ExperienceLevel, &j.VisaMentioned, &j.VisaSentiment, &j.IsNewGrad, &j.SkillScore, &j.SkillMatched, &j.SkillMissing, &j.SkillReason, NullableTime{&j.SkillScoredAt}, NullableTime{&j.ClosedAt}, &j.CanonicalID, &j.SalaryMin, &j.SalaryMax, &j.SalaryCurrency, &j.SalaryPeriod, JSONColumn{&j.Places}, &j.PlatformEmploymentType, &j.EmploymentType, JSONColumn{&j.Restrictions}, &j.OriginalDescription, NullableTime{&j.PlatformUpdatedAt}, NullableTime{&j.FirstSeenAt}, &j.FinalScore, JSONColumn{&j.ScoreBreakdown})
 	if err != nil {
 		return nil, fmt.Errorf("getting job: %w", err)
 	}
 	return j, nil
 }
 
+// JobQuery selects jobs for listing. The zero value lists every open job.
+type JobQuery struct {
+	// MinScore keeps jobs whose final score, or skill score for jobs not
+	// yet ranked, reaches this value.
+	MinScore         float64
+	CompanyID        string
+	OnlyNew          bool
+	OnlyRemote       bool
+	OnlyVisaFriendly bool
+	OnlyNewGrad      bool
+	InCartOnly       bool
+	// IncludeClosed also returns postings that have disappeared from their board.
+	IncludeClosed bool
+	// MinSalary keeps jobs whose stated pay, annualized, reaches this
+	// amount in SalaryCurrency. Jobs without a stated salary, or paying in
+	// another currency, are dropped.
+	MinSalary float64
+	// SalaryCurrency is the ISO code MinSalary is in; empty means USD.
+	SalaryCurrency string
+}
+
 func (d *DB) ListJobs(minScore float64, companyID string, onlyNew bool, onlyRemote bool, onlyVisaFriendly bool, onlyNewGrad bool, inCartOnly bool) ([]Job, error) {
+	return d.QueryJobs(JobQuery{
+		MinScore:         minScore,
+		CompanyID:        companyID,
+		OnlyNew:          onlyNew,
+		OnlyRemote:       onlyRemote,
+		OnlyVisaFriendly: onlyVisaFriendly,
+		OnlyNewGrad:      onlyNewGrad,
+		InCartOnly:       inCartOnly,
+	})
+}
+
+func (d *DB) QueryJobs(q JobQuery) ([]Job, error) {
 	where := "1=1"
 	var args []interface{}
 
-	if minScore > 0 {
-		where += " AND skill_score >= ?"
-		args = append(args, minScore)
+	if !q.IncludeClosed {
+		where += " AND j.closed_at IS NULL"
+	}
+	if q.MinScore > 0 {
+		where += " AND COALESCE(j.final_score, j.skill_score) >= ?"
+		args = append(args, q.MinScore)
 	}
-	if companyID != "" {
+	if q.CompanyID != "" {
 		where += " AND company_id = ?"
-		args = append(args, companyID)
+		args = append(args, q.CompanyID)
 	}
-	if onlyNew {
+	if q.OnlyNew {
 		where += " AND status = 'new'"
 	}
-	if onlyRemote {
+	if q.OnlyRemote {
 		where += " AND remote = 1"
 	}
-	if onlyVisaFriendly {
+	if q.OnlyVisaFriendly {
 		where += " AND (visa_sentiment = 'positive' OR visa_sentiment IS NULL OR visa_sentiment != 'negative')"
 		where += " AND company_id IN (SELECT id FROM companies WHERE sponsors_h1b = 1)"
 	}
-	if onlyNewGrad {
+	if q.OnlyNewGrad {
 		where += " AND is_new_grad = 1"
 	}
-	if inCartOnly {
+	if q.InCartOnly {
 		where += " AND company_id IN (SELECT id FROM companies WHERE in_cart = 1)"
 	}
+	if q.MinSalary > 0 {
+		currency := strings.ToUpper(q.SalaryCurrency)
+		if currency == "" {
+			currency = "USD"
+		}
+		where += ` AND salary_currency = ? AND COALESCE(salary_max, salary_min) * CASE salary_period
+			WHEN 'hour' THEN 2080 WHEN 'day' THEN 260 WHEN 'week' THEN 52 WHEN 'month' THEN 12 ELSE 1 END >= ?`
+		args = append(args, currency, q.MinSalary)
+	}
 
-	return d.listJobsWhere(where, args...)
+	// Best ranked first; jobs not yet ranked fall back to their skill score,
+	// and unscored jobs come last.
+	return d.selectJobs(`WHERE `+where+`
+		ORDER BY COALESCE(j.final_score, j.skill_score) IS NULL, COALESCE(j.final_score, j.skill_score) DESC, j.created_at DESC`, args...)
 }
 
+// SyncOpenJobs reconciles a company's jobs with the external IDs currently on
+// its board: jobs no longer listed are marked closed, and closed jobs that
+// reappear are reopened.
+func (d *DB) SyncOpenJobs(companyID string, externalIDs []string) (closed, reopened int, err error) {
+	placeholders := "NULL"
+	args := []interface{}{companyID}
+	if len(externalIDs) > 0 {
+		placeholders = strings.TrimSuffix(strings.Repeat("?,", len(externalIDs)), ",")
+		for _, id := range externalIDs {
+			args = append(args, id)
+		}
+	}
+
+	tx, err := d.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		`UPDATE jobs SET closed_at = CURRENT_TIMESTAMP
+		 WHERE company_id = ? AND closed_at IS NULL AND external_id NOT IN (`+placeholders+`)`, args...,
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("closing jobs: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	closed = int(n)
+
+	res, err = tx.Exec(
+		`UPDATE jobs SET closed_at = NULL
+		 WHERE company_id = ? AND closed_at IS NOT NULL AND external_id IN (`+placeholders+`)`, args...,
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("reopening jobs: %w", err)
+	}
+	n, _ = res.RowsAffected()
+	reopened = int(n)
+
+	return closed, reopened, tx.Commit()
+}
+
+// ListJobGroup returns every posting grouped with id as duplicates,
+// including the canonical one and closed ones, newest first.
+func (d *DB) ListJobGroup(id string) ([]Job, error) {
+	root := id
+	var canonical *string
+	if err := d.QueryRow(`SELECT canonical_id FROM jobs WHERE id = ?`, id).Scan(&canonical); err != nil {
+		return nil, fmt.Errorf("getting job: %w", err)
+	}
+	if canonical != nil {
+		root = *canonical
+	}
+	return d.listJobsWhere("(j.id = ? OR j.canonical_id = ?)", root, root)
+}
+
+// SetJobCanonical marks a job as a duplicate of canonicalID, or as canonical
+// itself when canonicalID is empty.
+func (d *DB) SetJobCanonical(id, canonicalID string) error {
+	var v interface{}
+	if canonicalID != "" {
+		v = canonicalID
+	}
+	_, err := d.Exec(`UPDATE jobs SET canonical_id = ? WHERE id = ?`, v, id)
+	return err
+}
 
 func (d *DB) ListUnscoredJobs() ([]Job, error) {
 	return d.listJobsWhere("skill_score IS NULL")
 }
 
 func (d *DB) listJobsWhere(where string, args ...interface{}) ([]Job, error) {
-	query := `SELECT j.id, j.company_id, COALESCE(c.name, '') as company_name, j.external_id, j.title, j.description, j.location, j.remote, j.department, j.skills, j.url, j.posted_at, j.scraped_at, j.match_score, j.match_reason, j.status, j.created_at, j.experience_level, j.visa_mentioned, j.visa_sentiment, j.is_new_grad, j.skill_score, j.skill_matched, j.skill_missing, j.skill_reason, j.skill_scored_at
+	return d.selectJobs(`WHERE `+where+` ORDER BY j.created_at DESC`, args...)
+}
+
+// ListJobsAfter returns up to limit jobs with IDs after afterID, in ID
+// order, for walking the whole table in batches.
+func (d *DB) ListJobsAfter(afterID string, limit int) ([]Job, error) {
+	return d.selectJobs(`WHERE j.id > ? ORDER BY j.id LIMIT ?`, afterID, limit)
+}
+
+// CountJobs returns the number of stored jobs, open or closed.
+func (d *DB) CountJobs() (int, error) {
+	var n int
+	if err := d.QueryRow(`SELECT COUNT(*) FROM jobs`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting jobs: %w", err)
+	}
+	return n, nil
+}
+
+// GetJobPayload returns the platform payload from a job's latest scrape, or
+// nil when none was kept.
+func (d *DB) GetJobPayload(id string) (json.RawMessage, error) {
+	var raw sql.NullString
+	if err := d.QueryRow(`SELECT raw_payload FROM jobs WHERE id = ?`, id).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("getting job payload: %w", err)
+	}
+	if !raw.Valid {
+		return nil, nil
+	}
+	return json.RawMessage(raw.String), nil
+}
+
+// selectJobs runs the job SELECT with tail (a WHERE and ORDER BY clause)
+// appended.
+func (d *DB) selectJobs(tail string, args ...interface{}) ([]Job, error) {
+	query := `SELECT j.id, j.company_id, COALESCE(c.name, '') as company_name, j.external_id, j.title, j.description, j.location, j.remote, j.department, j.skills, j.url, j.posted_at, j.scraped_at, j.match_score, j.match_reason, j.status, j.created_at, j.experience_level, j.visa_mentioned, j.visa_sentiment, j.is_new_grad, j.skill_score, j.skill_matched, j.skill_missing, j.skill_reason, j.skill_scored_at, j.closed_at, j.canonical_id, j.salary_min, j.salary_max, j.salary_currency, j.salary_period, j.places, j.platform_employment_type, j.employment_type, j.restrictions, j.description_original, j.platform_updated_at, j.first_seen_at, j.final_score, j.score_breakdown
 	FROM jobs j LEFT JOIN companies c ON j.company_id = c.id
-	WHERE ` + where + ` ORDER BY j.created_at DESC`
+	` + tail
 
 	rows, err := d.Query(query, args...)
 	if err != nil {
@@ -85,7 +481,7 @@ func (d *DB) listJobsWhere(where string, args ...interface{}) ([]Job, error) {
 	jobs := make([]Job, 0)
 	for rows.Next() {
 		var j Job
-		if err := rows.Scan(&j.ID, &j.CompanyID, &j.CompanyName, &j.ExternalID, &j.Title, &j.Description, &j.Location, &j.Remote, &j.Department, &j.Skills, &j.URL, NullableTime{&j.PostedAt}, NullableTime{&j.ScrapedAt}, &j.MatchScore, &j.MatchReason, &j.Status, RequiredTime{&j.CreatedAt}, &j.ExperienceLevel, &j.VisaMentioned, &j.VisaSentiment, &j.IsNewGrad, &j.SkillScore, &j.SkillMatched, &j.SkillMissing, &j.SkillReason, NullableTime{&j.SkillScoredAt}); err != nil {
+		if err := rows.Scan(&j.ID, &j.CompanyID, &j.CompanyName, &j.ExternalID, &j.Title, &j.Description, &j.Location, &j.Remote, &j.Department, &j.Skills, &j.URL, NullableTime{&j.PostedAt}, NullableTime{&j.ScrapedAt}, &j.MatchScore, &j.MatchReason, &j.Status, RequiredTime{&j.CreatedAt}, &j.ExperienceLevel, &j.VisaMentioned, &j.VisaSentiment, &j.IsNewGrad, &j.SkillScore, &j.SkillMatched, &j.SkillMissing, &j.SkillReason, NullableTime{&j.SkillScoredAt}, NullableTime{&j.ClosedAt}, &j.CanonicalID, &j.SalaryMin, &j.SalaryMax, &j.SalaryCurrency, &j.SalaryPeriod, JSONColumn{&j.Places}, &j.PlatformEmploymentType, &j.EmploymentType, JSONColumn{&j.Restrictions}, &j.OriginalDescription, NullableTime{&j.PlatformUpdatedAt}, NullableTime{&j.FirstSeenAt}, &j.FinalScore, JSONColumn{&j.ScoreBreakdown}); err != nil {
 			return nil, fmt.Errorf("scanning job: %w", err)
 		}
 		jobs = append(jobs, j)
@@ -111,6 +507,53 @@ func (d *DB) UpdateJobClassification(id string, experienceLevel string, isNewGra
 	return err
 }
 
+// UpdateJobEmploymentType stores a job's board label and the employment type
+// classified from it.
+func (d *DB) UpdateJobEmploymentType(id, platformType, employmentType string) error {
+	_, err := d.Exec(`UPDATE jobs SET platform_employment_type = ?, employment_type = ? WHERE id = ?`, nullString(platformType), employmentType, id)
+	return err
+}
+
+// UpdateJobPlaces stores the places parsed from a job's location.
+func (d *DB) UpdateJobPlaces(id string, places []Place) error {
+	_, err := d.Exec(`UPDATE jobs SET places = ? WHERE id = ?`, placesColumn(places), id)
+	return err
+}
+
+// UpdateJobRestrictions stores the eligibility restrictions found in a job.
+func (d *DB) UpdateJobRestrictions(id string, restrictions []Restriction) error {
+	_, err := d.Exec(`UPDATE jobs SET restrictions = ? WHERE id = ?`, restrictionsColumn(restrictions), id)
+	return err
+}
+
+// UpdateJobDescription replaces a job's normalized description and the
+// original it was derived from.
+func (d *DB) UpdateJobDescription(id, description, original string) error {
+	_, err := d.Exec(`UPDATE jobs SET description = ?, description_original = ? WHERE id = ?`, description, original, id)
+	return err
+}
+
+// UpdateJobSalary stores a job's stated pay.
+func (d *DB) UpdateJobSalary(id string, s *Salary) error {
+	min, max, currency, period := s.columns()
+	_, err := d.Exec(`UPDATE jobs SET salary_min = ?, salary_max = ?, salary_currency = ?, salary_period = ? WHERE id = ?`, min, max, currency, period, id)
+	return err
+}
+
+// UpdateJobSkills stores the skills extracted from a job's description, as
+// JSON.
+func (d *DB) UpdateJobSkills(id, skills string) error {
+	_, err := d.Exec(`UPDATE jobs SET skills = ? WHERE id = ?`, skills, id)
+	return err
+}
+
+// UpdateJobFinalScore stores a job's composite rank and its breakdown.
+func (d *DB) UpdateJobFinalScore(id string, score float64, breakdown []ScoreComponent) error {
+	data, _ := json.Marshal(breakdown)
+	_, err := d.Exec(`UPDATE jobs SET final_score = ?, score_breakdown = ? WHERE id = ?`, score, string(data), id)
+	return err
+}
+
 func (d *DB) ListUnclassifiedJobs() ([]Job, error) {
 	return d.listJobsWhere("experience_level IS NULL")
 }
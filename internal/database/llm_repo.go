@@ -0,0 +1,106 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqliteTime formats t the way CURRENT_TIMESTAMP stores it, so the two
+// compare as strings.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// GetLLMCache returns the cached response stored under key.
+func (d *DB) GetLLMCache(key string) (string, bool, error) {
+	var response string
+	err := d.QueryRow(`SELECT response FROM llm_cache WHERE key = ?`, key).Scan(&response)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("reading LLM cache: %w", err)
+	}
+	return response, true, nil
+}
+
+// PutLLMCache stores a response under key, replacing any older one.
+func (d *DB) PutLLMCache(key, provider, model, response string) error {
+	_, err := d.Exec(
+		`INSERT OR REPLACE INTO llm_cache (key, provider, model, response, created_at) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		key, provider, model, response,
+	)
+	if err != nil {
+		return fmt.Errorf("writing LLM cache: %w", err)
+	}
+	return nil
+}
+
+// CountLLMCache returns the number of cached responses.
+func (d *DB) CountLLMCache() (int, error) {
+	var n int
+	err := d.QueryRow(`SELECT COUNT(*) FROM llm_cache`).Scan(&n)
+	return n, err
+}
+
+// RecordLLMUsage logs one request or cache hit.
+func (d *DB) RecordLLMUsage(u LLMUsage) error {
+	_, err := d.Exec(
+		`INSERT INTO llm_usage (run_id, provider, model, input_tokens, output_tokens, cached) VALUES (?, ?, ?, ?, ?, ?)`,
+		u.RunID, u.Provider, u.Model, u.InputTokens, u.OutputTokens, u.Cached,
+	)
+	if err != nil {
+		return fmt.Errorf("recording LLM usage: %w", err)
+	}
+	return nil
+}
+
+// LLMTokensSince returns the input and output tokens spent since t.
+func (d *DB) LLMTokensSince(t time.Time) (int, error) {
+	var n int
+	err := d.QueryRow(
+		`SELECT COALESCE(SUM(input_tokens + output_tokens), 0) FROM llm_usage WHERE created_at >= ?`, sqliteTime(t),
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("summing LLM usage: %w", err)
+	}
+	return n, nil
+}
+
+// LLMTokensForRun returns the input and output tokens spent under runID.
+func (d *DB) LLMTokensForRun(runID string) (int, error) {
+	var n int
+	err := d.QueryRow(
+		`SELECT COALESCE(SUM(input_tokens + output_tokens), 0) FROM llm_usage WHERE run_id = ?`, runID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("summing LLM usage: %w", err)
+	}
+	return n, nil
+}
+
+// LLMUsageByDay totals usage since t per local calendar day, newest first.
+func (d *DB) LLMUsageByDay(t time.Time) ([]LLMUsageDay, error) {
+	rows, err := d.Query(
+		`SELECT date(created_at, 'localtime') AS day,
+		 SUM(CASE WHEN cached THEN 0 ELSE 1 END), SUM(CASE WHEN cached THEN 1 ELSE 0 END),
+		 SUM(input_tokens), SUM(output_tokens)
+		 FROM llm_usage WHERE created_at >= ?
+		 GROUP BY day ORDER BY day DESC`, sqliteTime(t),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing LLM usage: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var days []LLMUsageDay
+	for rows.Next() {
+		var u LLMUsageDay
+		if err := rows.Scan(&u.Day, &u.Requests, &u.CacheHits, &u.InputTokens, &u.OutputTokens); err != nil {
+			return nil, fmt.Errorf("scanning LLM usage: %w", err)
+		}
+		days = append(days, u)
+	}
+	return days, rows.Err()
+}
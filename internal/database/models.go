@@ -18,6 +18,13 @@ type Company struct {
 	InCart			bool		`json:"in_cart"`
 	CartAddedAt		*time.Time	`json:"cart_added_at"`
 	LastNotifiedAt	*time.Time	`json:"last_notified_at"`
+	VerifyStatus	*string		`json:"verify_status"`
+	VerifyError		*string		`json:"verify_error"`
+	VerifiedAt		*time.Time	`json:"verified_at"`
+	NotFoundCount	int			`json:"not_found_count"`
+	ETag			string		`json:"-"`
+	LastModified	string		`json:"-"`
+	ContentHash		string		`json:"-"`
 }
 
 type Job struct {
@@ -32,7 +39,12 @@ type Job struct {
 	Department		*string		`json:"department"`
 	Skills			*string		`json:"skills"`
 	URL				string		`json:"url"`
+	// PostedAt is when the board says the job was posted.
 	PostedAt		*time.Time	`json:"posted_at"`
+	// PlatformUpdatedAt is when the board says the job was last edited.
+	PlatformUpdatedAt	*time.Time	`json:"platform_updated_at"`
+	// FirstSeenAt is when jobgo first scraped the job.
+	FirstSeenAt		*time.Time	`json:"first_seen_at"`
 	ScrapedAt		*time.Time	`json:"scraped_at"`
 	MatchScore		*float64	`json:"match_score"`
 	MatchReason		*string		`json:"match_reason"`
@@ -47,6 +59,86 @@ type Job struct {
 	SkillMissing	*string		`json:"skill_missing"`
 	SkillReason		*string 	`json:"skill_reason"`
 	SkillScoredAt	*time.Time	`json:"skill_scored_at"`
+	ClosedAt		*time.Time	`json:"closed_at"`
+	// CanonicalID points at the earliest posting of the same role when this
+	// job is a repost or another location's copy of it.
+	CanonicalID		*string		`json:"canonical_id"`
+	// DuplicateCount is how many other postings were collapsed into this
+	// row when listing; it is not stored.
+	DuplicateCount	int			`json:"duplicate_count"`
+	SalaryMin		*float64	`json:"salary_min"`
+	SalaryMax		*float64	`json:"salary_max"`
+	SalaryCurrency	*string		`json:"salary_currency"`
+	// SalaryPeriod is what the amounts are quoted per: hour, day, week,
+	// month or year.
+	SalaryPeriod	*string		`json:"salary_period"`
+	// Places is the location parsed into structured fields; nil until the
+	// job has been parsed.
+	Places			[]Place		`json:"places"`
+	// PlatformEmploymentType is the board's own label, when it has one.
+	PlatformEmploymentType	*string	`json:"platform_employment_type"`
+	// EmploymentType is the classified type: full_time, part_time,
+	// contract or intern.
+	EmploymentType	*string		`json:"employment_type"`
+	// Restrictions are eligibility requirements found in the posting, such
+	// as citizenship or a security clearance; nil until the job has been
+	// checked, empty when it states none.
+	Restrictions	[]Restriction	`json:"restrictions"`
+	// OriginalDescription is the description as the board served it;
+	// Description holds it normalized to plain text.
+	OriginalDescription	*string	`json:"original_description,omitempty"`
+	// FinalScore is the composite rank blending skill fit with seniority,
+	// location, H1B and freshness; ScoreBreakdown shows how it was reached.
+	FinalScore		*float64	`json:"final_score"`
+	ScoreBreakdown	[]ScoreComponent	`json:"score_breakdown,omitempty"`
+}
+
+// ScoreComponent is one input to a job's final score.
+type ScoreComponent struct {
+	Name	string	`json:"name"`
+	// Score is the component's own 0–100 value, or for an adjustment such
+	// as H1B, the points it adds or removes.
+	Score	float64	`json:"score"`
+	// Weight is the component's share of the final score; zero for an
+	// adjustment.
+	Weight	float64	`json:"weight"`
+	// Points is what the component contributed to the final score.
+	Points	float64	`json:"points"`
+	Detail	string	`json:"detail,omitempty"`
+}
+
+// Place is one location a job is offered in. For a remote place, the
+// geographic fields give the scope the role is open to, and none of them
+// means anywhere.
+type Place struct {
+	City	string	`json:"city,omitempty"`
+	Region	string	`json:"region,omitempty"`
+	// Country is an ISO 3166-1 alpha-2 code.
+	Country	string	`json:"country,omitempty"`
+	// Area is a multi-country scope such as "EMEA" or "North America".
+	Area	string	`json:"area,omitempty"`
+	Remote	bool	`json:"remote,omitempty"`
+}
+
+// Restriction is an eligibility requirement a posting states, e.g. US
+// citizenship or a security clearance.
+type Restriction struct {
+	Kind	string	`json:"kind"`
+	// Detail refines the kind, such as a clearance level.
+	Detail	string	`json:"detail,omitempty"`
+	// Evidence is the text the restriction was found in.
+	Evidence	string	`json:"evidence,omitempty"`
+}
+
+// JobRevision is a snapshot of a job's content before a re-scrape changed it.
+type JobRevision struct {
+	ID			int64		`json:"id"`
+	JobID		string		`json:"job_id"`
+	Title		string		`json:"title"`
+	Description	string		`json:"description"`
+	Location	string		`json:"location"`
+	Department	string		`json:"department"`
+	CapturedAt	time.Time	`json:"captured_at"`
 }
 
 type Profile struct {
@@ -103,4 +195,22 @@ type H1bLcas struct {
 	SubmitDate		time.Time
 	DecisionDate	time.Time
 	Status			string
-}
\ No newline at end of file
+}
+// LLMUsage is one LLM scoring request, or a cache hit that saved one.
+type LLMUsage struct {
+	RunID			string
+	Provider		string
+	Model			string
+	InputTokens		int
+	OutputTokens	int
+	Cached			bool
+}
+
+// LLMUsageDay totals one day's LLM usage.
+type LLMUsageDay struct {
+	Day				string	`json:"day"`
+	Requests		int		`json:"requests"`
+	CacheHits		int		`json:"cache_hits"`
+	InputTokens		int		`json:"input_tokens"`
+	OutputTokens	int		`json:"output_tokens"`
+}
@@ -0,0 +1,218 @@
+// Package dedup groups postings of the same role: reposts under a new
+// external ID and one role listed separately per location or per board.
+package dedup
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
+)
+
+// maxDistance is the largest Hamming distance between two description
+// fingerprints that still counts as the same text.
+const maxDistance = 8
+
+var (
+	bracketRe = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+	entityRe  = regexp.MustCompile(`&#?[a-zA-Z0-9]+;`)
+	nonWordRe = regexp.MustCompile(`[^a-z0-9+#]+`)
+)
+
+// workplaceWords are title suffixes that describe where, not what.
+var workplaceWords = map[string]bool{
+	"remote": true, "hybrid": true, "onsite": true, "on site": true, "in office": true,
+}
+
+// NormalizeTitle reduces a title to the role it names: lower case, without
+// bracketed requisition notes and without " - <location>" style suffixes
+// that repeat the posting's location.
+func NormalizeTitle(title, location string) string {
+	t := strings.ToLower(bracketRe.ReplaceAllString(title, " "))
+	location = strings.ToLower(location)
+
+	parts := strings.FieldsFunc(t, func(r rune) bool { return r == '-' || r == '–' || r == '|' || r == '/' || r == ',' })
+	for len(parts) > 1 {
+		last := strings.TrimSpace(parts[len(parts)-1])
+		if !workplaceWords[last] && (location == "" || !strings.Contains(location, last)) {
+			break
+		}
+		parts = parts[:len(parts)-1]
+	}
+	return strings.TrimSpace(nonWordRe.ReplaceAllString(strings.Join(parts, " "), " "))
+}
+
+// Fingerprint is a 64-bit simhash of a description's word trigrams, so near
+// identical texts land a few bits apart. Empty text fingerprints to 0.
+func Fingerprint(description string) uint64 {
+	text := entityRe.ReplaceAllString(tagRe.ReplaceAllString(strings.ToLower(description), " "), " ")
+	words := strings.Fields(nonWordRe.ReplaceAllString(text, " "))
+	if len(words) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	add := func(shingle string) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shingle))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	if len(words) < 3 {
+		add(strings.Join(words, " "))
+	}
+	for i := 0; i+3 <= len(words); i++ {
+		add(strings.Join(words[i:i+3], " "))
+	}
+
+	var fp uint64
+	for i, w := range weights {
+		if w > 0 {
+			fp |= 1 << i
+		}
+	}
+	return fp
+}
+
+// similar reports whether two fingerprints describe the same text. Empty
+// descriptions fingerprint to 0 and are never similar to anything.
+func similar(a, b uint64) bool {
+	return a != 0 && b != 0 && bits.OnesCount64(a^b) <= maxDistance
+}
+
+// posting is what Group compares a job by once its company and title match.
+type posting struct {
+	id       string
+	fp       uint64
+	location string
+	url      string
+}
+
+// sameRole reports whether two postings with matching company and title are
+// the same role. Without a description on both sides there is no text to
+// compare, and a shared title alone is too weak (many roles on a career
+// site carry the same one), so they must share their location or URL.
+func sameRole(a, b posting) bool {
+	if a.fp != 0 && b.fp != 0 {
+		return similar(a.fp, b.fp)
+	}
+	return a.location != "" && a.location == b.location || a.url != "" && a.url == b.url
+}
+
+// Group finds duplicates among jobs and returns each job's canonical ID,
+// or "" for jobs that are canonical themselves. Jobs are duplicates when
+// their companies normalize to the same name, their titles normalize to the
+// same role and their descriptions are near identical (or, lacking a
+// description, their locations or URLs are identical). The earliest posting
+// in a group is its canonical job.
+func Group(jobs []database.Job) map[string]string {
+	sorted := make([]database.Job, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	buckets := make(map[string][]posting)
+	groups := make(map[string]string, len(jobs))
+	for _, j := range sorted {
+		location := ""
+		if j.Location != nil {
+			location = *j.Location
+		}
+		description := ""
+		if j.Description != nil {
+			description = *j.Description
+		}
+		company := h1b.NormalizeName(j.CompanyName)
+		if company == "" {
+			company = j.CompanyID
+		}
+		key := company + "\x00" + NormalizeTitle(j.Title, location)
+		p := posting{
+			id:       j.ID,
+			fp:       Fingerprint(description),
+			location: strings.ToLower(strings.TrimSpace(location)),
+			url:      j.URL,
+		}
+
+		groups[j.ID] = ""
+		for _, c := range buckets[key] {
+			if sameRole(c, p) {
+				groups[j.ID] = c.id
+				break
+			}
+		}
+		if groups[j.ID] == "" {
+			buckets[key] = append(buckets[key], p)
+		}
+	}
+	return groups
+}
+
+// Assign regroups every stored job and records the canonical IDs that
+// changed. It returns how many jobs are duplicates of another.
+func Assign(db *database.DB) (int, error) {
+	jobs, err := db.QueryJobs(database.JobQuery{IncludeClosed: true})
+	if err != nil {
+		return 0, err
+	}
+	groups := Group(jobs)
+
+	dupes := 0
+	for _, j := range jobs {
+		want := groups[j.ID]
+		if want != "" {
+			dupes++
+		}
+		have := ""
+		if j.CanonicalID != nil {
+			have = *j.CanonicalID
+		}
+		if have != want {
+			if err := db.SetJobCanonical(j.ID, want); err != nil {
+				return dupes, err
+			}
+		}
+	}
+	return dupes, nil
+}
+
+// Collapse keeps the first job listed from each duplicate group, in the
+// order given, and sets its DuplicateCount to the number of rows folded
+// into it.
+func Collapse(jobs []database.Job) []database.Job {
+	index := make(map[string]int)
+	out := make([]database.Job, 0, len(jobs))
+	for _, j := range jobs {
+		key := GroupKey(j)
+		if i, ok := index[key]; ok {
+			out[i].DuplicateCount++
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, j)
+	}
+	return out
+}
+
+// GroupKey identifies the duplicate group a job belongs to.
+func GroupKey(j database.Job) string {
+	if j.CanonicalID != nil {
+		return *j.CanonicalID
+	}
+	return j.ID
+}
@@ -0,0 +1,121 @@
+package dedup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+const backendDesc = `<p>We are hiring a backend engineer to build our payments platform in Go.</p>
+<p>You will design APIs, own services in production and work with Postgres and Kafka.</p>
+<ul><li>3+ years of experience</li><li>Strong Go skills</li><li>Experience with distributed systems</li></ul>`
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title, location, want string
+	}{
+		{"Backend Engineer", "", "backend engineer"},
+		{"Backend Engineer - New York", "New York, NY", "backend engineer"},
+		{"Backend Engineer – Remote", "United States", "backend engineer"},
+		{"Backend Engineer (R-1234)", "", "backend engineer"},
+		{"Backend Engineer, Payments", "London", "backend engineer payments"},
+		{"Full-Stack Engineer", "Berlin", "full stack engineer"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTitle(tt.title, tt.location); got != tt.want {
+			t.Errorf("NormalizeTitle(%q, %q) = %q, want %q", tt.title, tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprintSimilarity(t *testing.T) {
+	base := Fingerprint(backendDesc)
+	if base == 0 {
+		t.Fatal("expected a non-zero fingerprint")
+	}
+	if !similar(base, Fingerprint(strings.ReplaceAll(backendDesc, "<p>", "<p> "))) {
+		t.Error("markup differences should not change the fingerprint")
+	}
+	if !similar(base, Fingerprint(backendDesc+"<p>Location: Austin, TX</p>")) {
+		t.Error("a small addition should stay similar")
+	}
+	other := Fingerprint(`<p>Join our design team to shape the look and feel of our mobile apps.
+Figma, prototyping and user research are a daily part of the role, alongside close work with product managers.</p>`)
+	if similar(base, other) {
+		t.Error("unrelated descriptions should not be similar")
+	}
+}
+
+func job(id, company, title, location, desc string, created time.Time) database.Job {
+	return database.Job{ID: id, CompanyID: "c-" + company, CompanyName: company, Title: title, Location: &location, Description: &desc, CreatedAt: created}
+}
+
+func TestGroup(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 3, n, 0, 0, 0, 0, time.UTC) }
+	jobs := []database.Job{
+		job("repost", "Acme", "Backend Engineer", "Remote", backendDesc, day(10)),
+		job("orig", "Acme", "Backend Engineer", "Remote", backendDesc, day(1)),
+		job("nyc", "Acme, Inc.", "Backend Engineer - New York", "New York", backendDesc, day(2)),
+		job("other-team", "Acme", "Backend Engineer", "Remote", "<p>Own our search ranking stack: relevance, indexing pipelines and evaluation tooling in Python and Elasticsearch.</p>", day(3)),
+		job("other-co", "Globex", "Backend Engineer", "Remote", backendDesc, day(4)),
+	}
+
+	got := Group(jobs)
+	want := map[string]string{
+		"orig":       "",
+		"repost":     "orig",
+		"nyc":        "orig",
+		"other-team": "",
+		"other-co":   "",
+	}
+	for id, canonical := range want {
+		if got[id] != canonical {
+			t.Errorf("Group()[%s] = %q, want %q", id, got[id], canonical)
+		}
+	}
+}
+
+func TestGroupWithoutDescriptions(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 3, n, 0, 0, 0, 0, time.UTC) }
+	jobs := []database.Job{
+		job("austin", "Acme", "Software Engineer", "Austin, TX", "", day(1)),
+		job("denver", "Acme", "Software Engineer", "Denver, CO", "", day(2)),
+		job("austin-repost", "Acme", "Software Engineer", "Austin, TX", "", day(3)),
+		job("described", "Acme", "Software Engineer", "Denver, CO", backendDesc, day(4)),
+	}
+
+	got := Group(jobs)
+	want := map[string]string{
+		"austin":        "",
+		"denver":        "",
+		"austin-repost": "austin",
+		"described":     "denver",
+	}
+	for id, canonical := range want {
+		if got[id] != canonical {
+			t.Errorf("Group()[%s] = %q, want %q", id, got[id], canonical)
+		}
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	orig := "orig"
+	jobs := []database.Job{
+		{ID: "repost", CanonicalID: &orig},
+		{ID: "solo"},
+		{ID: "orig"},
+		{ID: "nyc", CanonicalID: &orig},
+	}
+	got := Collapse(jobs)
+	if len(got) != 2 {
+		t.Fatalf("got %d rows, want 2", len(got))
+	}
+	if got[0].ID != "repost" || got[0].DuplicateCount != 2 {
+		t.Errorf("first row = %s with %d duplicates, want repost with 2", got[0].ID, got[0].DuplicateCount)
+	}
+	if got[1].ID != "solo" || got[1].DuplicateCount != 0 {
+		t.Errorf("second row = %s with %d duplicates, want solo with 0", got[1].ID, got[1].DuplicateCount)
+	}
+}
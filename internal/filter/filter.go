@@ -3,8 +3,12 @@ package filter
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/freshness"
+	"github.com/Trungsherlock/jobgo/internal/geo"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
 )
 
 type Filter interface {
@@ -66,15 +70,11 @@ func (f *TitleFilter) Apply(job database.Job) bool {
 	return false
 }
 
-var locationAliases = map[string]string{
-	"us":  "united states",
-	"usa": "united states",
-	"uk":  "united kingdom",
-	"gb":  "united kingdom",
-}
-
+// LocationFilter matches jobs whose parsed location satisfies any of
+// Locations; see geo.Query.Match for how "remote" is scoped.
 type LocationFilter struct {
 	Locations []string
+	query     *geo.Query
 }
 
 func (f *LocationFilter) Name() string {return "location"}
@@ -83,26 +83,16 @@ func (f *LocationFilter) Apply(job database.Job) bool {
 	if len(f.Locations) == 0 {
 		return true
 	}
+	if f.query == nil {
+		q := geo.ParseQuery(f.Locations)
+		f.query = &q
+	}
 	jobLocation := ""
 	if job.Location != nil {
-		jobLocation = strings.ToLower(*job.Location)
-	}
-	for _, loc := range f.Locations {
-		locLower := strings.ToLower(strings.TrimSpace(loc))
-		if locLower == "remote" {
-			if job.Remote || strings.Contains(jobLocation, "remote") {
-				return true
-			}
-			continue
-		}
-		if strings.Contains(jobLocation, locLower) {
-			return true
-		}
-		if expanded, ok := locationAliases[locLower]; ok && strings.Contains(jobLocation, expanded) {
-			return true
-		}
+		jobLocation = *job.Location
 	}
-	return false
+	_, ok := f.query.Match(geo.PlacesOf(job), jobLocation)
+	return ok
 }
 
 var seniorPattern = regexp.MustCompile(`(?i)\b(senior|staff|lead|sr\.?|principal|ii|iii|[23])\b`)
@@ -134,11 +124,78 @@ func (f *H1BFilter) Apply(job database.Job) bool {
 	return f.SponsorIDs[job.CompanyID]
 }
 
+// EmploymentTypeFilter matches jobs of any of Types (full_time, part_time,
+// contract, intern, or a platform label such as "Internship"). Jobs stored
+// before they were classified are classified on the fly.
+type EmploymentTypeFilter struct {
+	Types []string
+}
+
+func (f *EmploymentTypeFilter) Name() string { return "employment_type" }
+
+func (f *EmploymentTypeFilter) Apply(job database.Job) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	jobType := ""
+	if job.EmploymentType != nil {
+		jobType = *job.EmploymentType
+	} else {
+		platform, description := "", ""
+		if job.PlatformEmploymentType != nil {
+			platform = *job.PlatformEmploymentType
+		}
+		if job.Description != nil {
+			description = *job.Description
+		}
+		jobType = h1b.ClassifyEmploymentType(platform, job.Title, description)
+	}
+	for _, t := range f.Types {
+		if h1b.NormalizeEmploymentType(t) == jobType {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictionFilter drops jobs that state an eligibility restriction:
+// citizenship, permanent residency, no sponsorship, a security clearance or
+// export controls.
+type RestrictionFilter struct{}
+
+func (f *RestrictionFilter) Name() string { return "restricted" }
+
+func (f *RestrictionFilter) Apply(job database.Job) bool {
+	return len(h1b.RestrictionsOf(job)) == 0
+}
+
+// PostedWithinFilter keeps jobs posted no more than Within ago, going by the
+// board's posting date or, failing that, when the job was first seen.
+type PostedWithinFilter struct {
+	Within time.Duration
+	// Now defaults to the current time.
+	Now time.Time
+}
+
+func (f *PostedWithinFilter) Name() string { return "posted_within" }
+
+func (f *PostedWithinFilter) Apply(job database.Job) bool {
+	now := f.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	age, ok := freshness.Age(job, now)
+	return ok && age <= f.Within
+}
+
 type Params struct {
     Titles    []string
     Locations []string
     NewGrad   bool
     H1BOnly   bool
+    EmploymentTypes []string
+    ExcludeRestricted bool
+    PostedWithin time.Duration
 }
 
 func Build(p Params, h1bSponsorIDs map[string]bool) []Filter {
@@ -155,5 +212,14 @@ func Build(p Params, h1bSponsorIDs map[string]bool) []Filter {
     if p.H1BOnly {
         filters = append(filters, &H1BFilter{SponsorIDs: h1bSponsorIDs})
     }
+    if len(p.EmploymentTypes) > 0 {
+        filters = append(filters, &EmploymentTypeFilter{Types: p.EmploymentTypes})
+    }
+    if p.ExcludeRestricted {
+        filters = append(filters, &RestrictionFilter{})
+    }
+    if p.PostedWithin > 0 {
+        filters = append(filters, &PostedWithinFilter{Within: p.PostedWithin})
+    }
     return filters
 }
\ No newline at end of file
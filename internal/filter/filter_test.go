@@ -2,6 +2,7 @@ package filter
 
 import (
     "testing"
+    "time"
 
     "github.com/Trungsherlock/jobgo/internal/database"
 )
@@ -70,6 +71,26 @@ func TestLocationFilter_Country(t *testing.T) {
     }
 }
 
+func TestLocationFilter_Structured(t *testing.T) {
+    nyc := &LocationFilter{Locations: []string{"NYC"}}
+    if !nyc.Apply(database.Job{Location: strPtr("New York, NY")}) {
+        t.Error("NYC should match New York, NY")
+    }
+
+    usRemote := &LocationFilter{Locations: []string{"US", "remote"}}
+    if usRemote.Apply(database.Job{Location: strPtr("Remote - Canada"), Remote: true}) {
+        t.Error("remote for a US candidate should not match a Canada-only remote role")
+    }
+    if !usRemote.Apply(database.Job{Location: strPtr("Remote - US"), Remote: true}) {
+        t.Error("should pass US remote role")
+    }
+
+    parsed := database.Job{Places: []database.Place{{City: "Austin", Region: "Texas", Country: "US"}}}
+    if !(&LocationFilter{Locations: []string{"Texas"}}).Apply(parsed) {
+        t.Error("should match on stored places")
+    }
+}
+
 func TestNewGradFilter(t *testing.T) {
     f := &NewGradFilter{}
 
@@ -116,3 +137,50 @@ func TestApply_MultipleFilters(t *testing.T) {
         t.Errorf("expected 1 job, got %d: %v", len(result), result)
     }
 }
+
+func TestEmploymentTypeFilter(t *testing.T) {
+    f := &EmploymentTypeFilter{Types: []string{"intern", "Contract"}}
+    intern := "intern"
+    full := "full_time"
+    if !f.Apply(database.Job{Title: "Software Engineer", EmploymentType: &intern}) {
+        t.Error("should match stored intern type")
+    }
+    if f.Apply(database.Job{Title: "Software Engineer Intern", EmploymentType: &full}) {
+        t.Error("stored type should win over the title")
+    }
+    if !f.Apply(database.Job{Title: "Backend Engineer (Contract)"}) {
+        t.Error("unclassified contract job should be classified on the fly")
+    }
+    if f.Apply(database.Job{Title: "Backend Engineer"}) {
+        t.Error("unclassified full-time job should not match")
+    }
+}
+
+func TestRestrictionFilter(t *testing.T) {
+    f := &RestrictionFilter{}
+    if f.Apply(database.Job{Restrictions: []database.Restriction{{Kind: "clearance"}}}) {
+        t.Error("job with a stored restriction should be excluded")
+    }
+    if !f.Apply(database.Job{Title: "Backend Engineer", Restrictions: []database.Restriction{}}) {
+        t.Error("job checked with no restrictions should pass")
+    }
+    if f.Apply(database.Job{Title: "Backend Engineer", Description: strPtr("Must be a US citizen.")}) {
+        t.Error("unchecked job should be checked on the fly")
+    }
+}
+
+func TestPostedWithinFilter(t *testing.T) {
+    now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+    ago := func(days int) *time.Time { t := now.AddDate(0, 0, -days); return &t }
+    f := &PostedWithinFilter{Within: 7 * 24 * time.Hour, Now: now}
+
+    if !f.Apply(database.Job{PostedAt: ago(3)}) {
+        t.Error("job posted 3 days ago should pass")
+    }
+    if f.Apply(database.Job{PostedAt: ago(30), PlatformUpdatedAt: ago(1), FirstSeenAt: ago(1)}) {
+        t.Error("an old posting edited or first seen recently is still old")
+    }
+    if !f.Apply(database.Job{FirstSeenAt: ago(2)}) {
+        t.Error("without a posting date, first seen should count")
+    }
+}
@@ -0,0 +1,93 @@
+// Package freshness measures how old a posting is and how much of its score
+// a posting of that age keeps.
+package freshness
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/spf13/viper"
+)
+
+// PostedAt returns when a job was posted: the board's own posting date when
+// it gives one, otherwise when jobgo first saw the job. The board's
+// last-updated date is never used, since editing a posting does not make it
+// new.
+func PostedAt(job database.Job) (time.Time, bool) {
+	switch {
+	case job.PostedAt != nil:
+		return *job.PostedAt, true
+	case job.FirstSeenAt != nil:
+		return *job.FirstSeenAt, true
+	case !job.CreatedAt.IsZero():
+		return job.CreatedAt, true
+	}
+	return time.Time{}, false
+}
+
+// Age returns how long ago a job was posted, or false when that is unknown.
+func Age(job database.Job, now time.Time) (time.Duration, bool) {
+	t, ok := PostedAt(job)
+	if !ok {
+		return 0, false
+	}
+	if age := now.Sub(t); age > 0 {
+		return age, true
+	}
+	return 0, true
+}
+
+// ParseAge parses a duration such as "7d", "2w", "36h" or "90m".
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(v * float64(unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q: use e.g. 7d, 2w or 36h", s)
+	}
+	return d, nil
+}
+
+// FormatAge renders an age compactly: "5h", "3d", "6w".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return "<1h"
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 8*7*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dw", int(d.Hours()/(24*7)))
+}
+
+// Factor is the weight a posting of the given age keeps when scores halve
+// every halfLife. A zero halfLife disables decay.
+func Factor(age, halfLife time.Duration) float64 {
+	if halfLife <= 0 || age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// HalfLife returns the configured ranking.stale_half_life, or zero when
+// stale postings should not be decayed.
+func HalfLife() time.Duration {
+	d, err := ParseAge(viper.GetString("ranking.stale_half_life"))
+	if err != nil {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,53 @@
+package freshness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+func TestParseAge(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"7d":   7 * 24 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"36h":  36 * time.Hour,
+		"1.5d": 36 * time.Hour,
+	} {
+		got, err := ParseAge(in)
+		if err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "soon", "-3d"} {
+		if _, err := ParseAge(in); err == nil {
+			t.Errorf("ParseAge(%q) should fail", in)
+		}
+	}
+}
+
+func TestPostedAtIgnoresPlatformUpdates(t *testing.T) {
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	seen := now.Add(-40 * 24 * time.Hour)
+	edited := now.Add(-time.Hour)
+	job := database.Job{FirstSeenAt: &seen, PlatformUpdatedAt: &edited}
+	if age, ok := Age(job, now); !ok || age != 40*24*time.Hour {
+		t.Errorf("Age = %v, %v; want 40 days from first seen", age, ok)
+	}
+
+	posted := now.Add(-3 * 24 * time.Hour)
+	job.PostedAt = &posted
+	if age, _ := Age(job, now); age != 3*24*time.Hour {
+		t.Errorf("Age = %v, want the board's posting date to win", age)
+	}
+}
+
+func TestFactor(t *testing.T) {
+	halfLife := 14 * 24 * time.Hour
+	if f := Factor(halfLife, halfLife); f != 0.5 {
+		t.Errorf("Factor at one half-life = %v, want 0.5", f)
+	}
+	if f := Factor(30*24*time.Hour, 0); f != 1 {
+		t.Errorf("Factor without a half-life = %v, want 1", f)
+	}
+}
@@ -0,0 +1,262 @@
+// Package geo parses free-text job locations into structured places using
+// a bundled offline gazetteer, and matches them against location queries.
+package geo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+//go:embed gazetteer.csv
+var gazetteerCSV string
+
+// Entry kinds, in the order an ambiguous name prefers them: "New York" is
+// the city before the state, "CA" is California before Canada.
+const (
+	kindCity = iota
+	kindRegion
+	kindCountry
+	kindArea
+)
+
+type entry struct {
+	kind    int
+	name    string
+	region  string
+	country string
+	areas   []string
+}
+
+var (
+	loadOnce sync.Once
+	// index maps a normalized name or alias to its entries, most likely first.
+	index map[string][]*entry
+	// countryAreas lists the areas each country code belongs to.
+	countryAreas map[string][]string
+)
+
+func load() {
+	index = make(map[string][]*entry)
+	countryAreas = make(map[string][]string)
+
+	r := csv.NewReader(strings.NewReader(gazetteerCSV))
+	r.Comment = '#'
+	r.FieldsPerRecord = 6
+	records, err := r.ReadAll()
+	if err != nil {
+		panic("geo: malformed gazetteer: " + err.Error())
+	}
+
+	kinds := map[string]int{"city": kindCity, "region": kindRegion, "country": kindCountry, "area": kindArea}
+	var entries []*entry
+	for _, rec := range records[1:] {
+		e := &entry{kind: kinds[rec[0]], name: rec[1], region: rec[3], country: rec[4]}
+		if rec[5] != "" {
+			e.areas = strings.Split(rec[5], "|")
+		}
+		if e.kind == kindRegion {
+			e.region = e.name
+		}
+		if e.kind == kindCountry {
+			countryAreas[e.country] = e.areas
+		}
+		entries = append(entries, e)
+
+		names := []string{rec[1]}
+		if rec[2] != "" {
+			names = append(names, strings.Split(rec[2], "|")...)
+		}
+		for _, n := range names {
+			key := normalize(n)
+			index[key] = append(index[key], e)
+		}
+	}
+
+	// Keep each name's candidates in kind order, file order within a kind.
+	for key, cands := range index {
+		sorted := make([]*entry, 0, len(cands))
+		for k := kindCity; k <= kindArea; k++ {
+			for _, e := range cands {
+				if e.kind == k {
+					sorted = append(sorted, e)
+				}
+			}
+		}
+		index[key] = sorted
+	}
+}
+
+func lookup(part string) []*entry {
+	loadOnce.Do(load)
+	return index[normalize(part)]
+}
+
+var spaceRe = regexp.MustCompile(`\s+`)
+
+func normalize(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, ".", ""))
+	return strings.TrimSpace(spaceRe.ReplaceAllString(s, " "))
+}
+
+var (
+	// workdayRe matches Workday's "US-CA-San Francisco" location style.
+	workdayRe = regexp.MustCompile(`^([A-Z]{2})-([A-Z]{2})-(.+)$`)
+	chunkRe   = regexp.MustCompile(`\s*(?:[;|•\n]|\s/\s|\bor\b)\s*`)
+	partRe    = regexp.MustCompile(`\s*(?:[,():]|\s[-–—]\s)\s*`)
+	remoteRe  = regexp.MustCompile(`(?i)\b(?:remote|anywhere|worldwide|work from home|wfh|distributed|global)\b`)
+	// "in" is only filler in lower case; "IN" is Indiana or India.
+	fillerRe = regexp.MustCompile(`\b(?:(?i:hybrid|on-?site|in-office|office|only|based|hq|headquarters|greater|metro(?:politan)?|area|within|from|the)|in)\b`)
+)
+
+// Parse splits a posting's location into places. remote is the platform's
+// own remote flag; when set and the text names no remote place, a remote
+// place is added for the posting's country, or worldwide when it has none.
+func Parse(location string, remote bool) []database.Place {
+	location = strings.TrimSpace(location)
+	if m := workdayRe.FindStringSubmatch(location); m != nil {
+		location = m[3] + ", " + m[2] + ", " + m[1]
+	}
+
+	// Never nil, so a stored result is told apart from "not parsed yet".
+	places := []database.Place{}
+	for _, chunk := range chunkRe.Split(location, -1) {
+		places = append(places, parseChunk(chunk)...)
+	}
+
+	if remote {
+		for _, p := range places {
+			if p.Remote {
+				return places
+			}
+		}
+		r := database.Place{Remote: true}
+		if c := commonCountry(places); c != "" {
+			r.Country = c
+		}
+		places = append(places, r)
+	}
+	return places
+}
+
+// parseChunk resolves one place description such as "San Francisco, CA" or
+// "Remote - Canada". Several cities in one chunk become several places.
+func parseChunk(chunk string) []database.Place {
+	remote := remoteRe.MatchString(chunk)
+	chunk = fillerRe.ReplaceAllString(remoteRe.ReplaceAllString(chunk, " "), " ")
+
+	var cands [][]*entry
+	for _, part := range partRe.Split(chunk, -1) {
+		if c := lookup(part); len(c) > 0 {
+			cands = append(cands, c)
+		}
+	}
+	resolved := resolve(cands)
+
+	var cities, regions, countries, areas []*entry
+	for _, e := range resolved {
+		switch e.kind {
+		case kindCity:
+			cities = append(cities, e)
+		case kindRegion:
+			regions = append(regions, e)
+		case kindCountry:
+			countries = append(countries, e)
+		case kindArea:
+			areas = append(areas, e)
+		}
+	}
+	area := ""
+	if len(areas) > 0 {
+		area = areas[0].name
+	}
+
+	var places []database.Place
+	switch {
+	case len(cities) > 0:
+		for _, e := range cities {
+			places = append(places, database.Place{City: e.name, Region: e.region, Country: e.country})
+		}
+	case len(regions) > 0:
+		for _, e := range regions {
+			places = append(places, database.Place{Region: e.name, Country: e.country})
+		}
+	case len(countries) > 0:
+		for _, e := range countries {
+			places = append(places, database.Place{Country: e.country})
+		}
+	case area != "" || remote:
+		places = append(places, database.Place{})
+	}
+	for i := range places {
+		places[i].Area = area
+		places[i].Remote = remote
+	}
+	return places
+}
+
+// resolve picks one entry per part, preferring entries that agree with the
+// parts that are unambiguous: in "Cambridge, UK" the UK settles which
+// Cambridge, and in "San Francisco, CA" the city settles which CA.
+func resolve(cands [][]*entry) []*entry {
+	var anchors []*entry
+	for _, c := range cands {
+		if len(c) == 1 {
+			anchors = append(anchors, c[0])
+		}
+	}
+
+	resolved := make([]*entry, 0, len(cands))
+	for _, c := range cands {
+		pick := c[0]
+		for _, e := range c {
+			if consistent(e, anchors) {
+				pick = e
+				break
+			}
+		}
+		resolved = append(resolved, pick)
+	}
+	return resolved
+}
+
+func consistent(e *entry, anchors []*entry) bool {
+	for _, a := range anchors {
+		if a == e || a.kind == kindArea || e.kind == kindArea {
+			continue
+		}
+		if a.country != e.country {
+			return false
+		}
+		if a.region != "" && e.region != "" && a.region != e.region {
+			return false
+		}
+	}
+	return true
+}
+
+func commonCountry(places []database.Place) string {
+	country := ""
+	for _, p := range places {
+		if p.Country == "" || (country != "" && p.Country != country) {
+			return ""
+		}
+		country = p.Country
+	}
+	return country
+}
+
+// inArea reports whether a country code belongs to an area such as EMEA.
+func inArea(country, area string) bool {
+	loadOnce.Do(load)
+	for _, a := range countryAreas[country] {
+		if a == area {
+			return true
+		}
+	}
+	return false
+}
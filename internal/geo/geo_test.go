@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		location string
+		remote   bool
+		want     []database.Place
+	}{
+		{"New York, NY", false, []database.Place{{City: "New York", Region: "New York", Country: "US"}}},
+		{"NYC", false, []database.Place{{City: "New York", Region: "New York", Country: "US"}}},
+		{"San Francisco, CA", false, []database.Place{{City: "San Francisco", Region: "California", Country: "US"}}},
+		{"Toronto, ON, CA", false, []database.Place{{City: "Toronto", Region: "Ontario", Country: "CA"}}},
+		{"Cambridge, UK", false, []database.Place{{City: "Cambridge", Country: "GB"}}},
+		{"London, ON", false, []database.Place{{City: "London", Region: "Ontario", Country: "CA"}}},
+		{"Indianapolis, IN", false, []database.Place{{Region: "Indiana", Country: "US"}}},
+		{"US-CA-San Jose", false, []database.Place{{City: "San Jose", Region: "California", Country: "US"}}},
+		{"Remote - Canada", false, []database.Place{{Country: "CA", Remote: true}}},
+		{"Remote (EMEA)", false, []database.Place{{Area: "EMEA", Remote: true}}},
+		{"Remote", false, []database.Place{{Remote: true}}},
+		{"Hybrid - Berlin", false, []database.Place{{City: "Berlin", Country: "DE"}}},
+		{"San Francisco, New York; Remote - US", false, []database.Place{
+			{City: "San Francisco", Region: "California", Country: "US"},
+			{City: "New York", Region: "New York", Country: "US"},
+			{Country: "US", Remote: true},
+		}},
+		{"United States", true, []database.Place{{Country: "US"}, {Country: "US", Remote: true}}},
+		{"Somewhere Unknown", false, []database.Place{}},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.location, tt.remote)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q, %v) = %+v, want %+v", tt.location, tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	tests := []struct {
+		query    []string
+		location string
+		remote   bool
+		want     bool
+	}{
+		{[]string{"NYC"}, "New York, NY", false, true},
+		{[]string{"New York"}, "Brooklyn, New York", false, true},
+		{[]string{"US"}, "San Francisco, CA", false, true},
+		{[]string{"US"}, "Remote - US", false, true},
+		{[]string{"US"}, "London, UK", false, false},
+		{[]string{"California"}, "Seattle, WA", false, false},
+		{[]string{"Europe"}, "Berlin, Germany", false, true},
+		{[]string{"remote"}, "Remote - Canada", false, true},
+		{[]string{"US", "remote"}, "Remote - Canada", false, false},
+		{[]string{"US", "remote"}, "Remote", false, true},
+		{[]string{"US", "remote"}, "Remote - North America", false, true},
+		{[]string{"US", "remote"}, "Remote (EMEA)", false, false},
+		{[]string{"remote US"}, "Anywhere", true, true},
+		{[]string{"remote"}, "New York", false, false},
+		{[]string{"Springfield"}, "Springfield Office", false, true},
+	}
+	for _, tt := range tests {
+		_, got := ParseQuery(tt.query).Match(Parse(tt.location, tt.remote), tt.location)
+		if got != tt.want {
+			t.Errorf("%v matching %q = %v, want %v", tt.query, tt.location, got, tt.want)
+		}
+	}
+}
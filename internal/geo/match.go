@@ -0,0 +1,160 @@
+package geo
+
+import (
+	"strings"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+// Query is a set of location preferences such as ["US", "remote"].
+type Query struct {
+	terms []term
+	// scope is every place named by the non-remote terms; a bare "remote"
+	// term is limited to roles open to one of them.
+	scope []database.Place
+}
+
+type term struct {
+	raw    string
+	remote bool
+	places []database.Place
+}
+
+// ParseQuery resolves location preferences with the same gazetteer used for
+// postings. Terms it can't resolve fall back to substring matching.
+func ParseQuery(terms []string) Query {
+	var q Query
+	for _, raw := range terms {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		t := term{raw: raw, remote: remoteRe.MatchString(raw)}
+		for _, p := range Parse(raw, false) {
+			if p.City != "" || p.Region != "" || p.Country != "" || p.Area != "" {
+				t.places = append(t.places, p)
+			}
+		}
+		if !t.remote {
+			q.scope = append(q.scope, t.places...)
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q
+}
+
+// Empty reports whether the query has no terms, so everything matches.
+func (q Query) Empty() bool {
+	return len(q.terms) == 0
+}
+
+// Match reports whether any of places satisfies the query, and which term
+// it matched. rawLocation is used for terms the gazetteer doesn't know.
+//
+// A place term matches postings in that place, including remote postings
+// restricted to it ("Remote - US" is a US job). "remote" matches remote
+// postings open to the query's places, so ["US", "remote"] excludes
+// "Remote - Canada"; on its own it matches any remote posting.
+func (q Query) Match(places []database.Place, rawLocation string) (string, bool) {
+	for _, t := range q.terms {
+		switch {
+		case t.remote:
+			scope := t.places
+			if len(scope) == 0 {
+				scope = q.scope
+			}
+			for _, p := range places {
+				if p.Remote && openTo(p, scope) {
+					return t.raw, true
+				}
+			}
+		case len(t.places) > 0:
+			for _, p := range places {
+				for _, want := range t.places {
+					if within(p, want) {
+						return t.raw, true
+					}
+				}
+			}
+		default:
+			if strings.Contains(strings.ToLower(rawLocation), strings.ToLower(t.raw)) {
+				return t.raw, true
+			}
+		}
+	}
+	return "", false
+}
+
+// within reports whether p lies inside want, at want's level of detail.
+func within(p, want database.Place) bool {
+	switch {
+	case want.City != "":
+		return p.City == want.City && p.Country == want.Country
+	case want.Region != "":
+		return p.Region == want.Region && p.Country == want.Country
+	case want.Country != "":
+		return p.Country == want.Country
+	case want.Area != "":
+		return p.Area == want.Area || inArea(p.Country, want.Area)
+	}
+	return false
+}
+
+// openTo reports whether a remote place accepts candidates from any of
+// scope. Remote places with no scope are open to everyone, and an empty
+// scope accepts every remote place.
+func openTo(p database.Place, scope []database.Place) bool {
+	if len(scope) == 0 || (p.Country == "" && p.Area == "") {
+		return true
+	}
+	for _, s := range scope {
+		switch {
+		case p.Country != "" && p.Country == s.Country:
+			if p.Region == "" || s.Region == "" || p.Region == s.Region {
+				return true
+			}
+		case p.Country == "" && p.Area != "":
+			if p.Area == s.Area || inArea(s.Country, p.Area) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PlacesOf returns a job's parsed places, parsing its location now if it
+// was stored before locations were parsed.
+func PlacesOf(j database.Job) []database.Place {
+	if j.Places != nil {
+		return j.Places
+	}
+	location := ""
+	if j.Location != nil {
+		location = *j.Location
+	}
+	return Parse(location, j.Remote)
+}
+
+// Describe renders places for display, e.g. "San Francisco, California, US;
+// Remote (US)".
+func Describe(places []database.Place) string {
+	var out []string
+	for _, p := range places {
+		var parts []string
+		for _, s := range []string{p.City, p.Region, p.Country, p.Area} {
+			if s != "" {
+				parts = append(parts, s)
+			}
+		}
+		text := strings.Join(parts, ", ")
+		if p.Remote {
+			if text == "" {
+				text = "Remote (anywhere)"
+			} else {
+				text = "Remote (" + text + ")"
+			}
+		}
+		out = append(out, text)
+	}
+	return strings.Join(out, "; ")
+}
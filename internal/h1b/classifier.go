@@ -71,6 +71,13 @@ func detectExperienceLevel(title, combined string) (string, bool) {
 	return "mid", false
 }
 
+// LevelForYears is the experience level a job asking for n years of
+// experience is classified as, and so the level of a candidate with n years.
+func LevelForYears(n int) string {
+	level, _ := levelByYears(n)
+	return level
+}
+
 func levelByYears(n int) (string, bool) {
 	switch {
 	case n >= 5:
@@ -0,0 +1,85 @@
+package h1b
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Employment types stored on jobs.
+const (
+	FullTime = "full_time"
+	PartTime = "part_time"
+	Contract = "contract"
+	Intern   = "intern"
+)
+
+// EmploymentTypes lists the valid employment types.
+var EmploymentTypes = []string{FullTime, PartTime, Contract, Intern}
+
+var employmentAliases = []struct {
+	re  *regexp.Regexp
+	typ string
+}{
+	// Order matters: "Part-time Internship" is an internship and "Full-time
+	// contract" a contract. Aliases are whole words, so "International" is
+	// not an intern label.
+	{regexp.MustCompile(`\b(?:interns?|internships?|co-?op|apprentice(?:ship)?s?|trainee|placement|working student|werkstudent)\b`), Intern},
+	{regexp.MustCompile(`\b(?:contract(?:or)?s?|temporary|temp|freelancer?|fixed[-_ ]?term|seasonal|consultant)\b`), Contract},
+	{regexp.MustCompile(`\b(?:part[-_ ]?time|pt)\b`), PartTime},
+	{regexp.MustCompile(`\b(?:full[-_ ]?time|ft|permanent|regular|salaried|employee)\b`), FullTime},
+}
+
+// NormalizeEmploymentType maps a platform label ("FullTime", "Intern",
+// "Contractor", "Part-time") or a user's filter value onto one of the
+// employment types, or "" when it names none.
+func NormalizeEmploymentType(label string) string {
+	// Underscores join words in labels like "FULL_TIME" but are word
+	// characters to \b.
+	label = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(label)), "_", " ")
+	if label == "" {
+		return ""
+	}
+	for _, a := range employmentAliases {
+		if a.re.MatchString(label) {
+			return a.typ
+		}
+	}
+	return ""
+}
+
+var (
+	titleInternRE   = regexp.MustCompile(`(?i)\b(?:intern|internship|co-?op|apprentice(?:ship)?|werkstudent)\b`)
+	titleContractRE = regexp.MustCompile(`(?i)\b(?:contract(?:or)?|temp|temporary|freelance|fixed[- ]term|\d+[- ]months?)\b`)
+	titlePartTimeRE = regexp.MustCompile(`(?i)\bpart[- ]time\b`)
+	// descTypeRE catches postings that state their type in prose: "This is
+	// a 6-month contract role", "this is a part-time position".
+	descTypeRE = regexp.MustCompile(`(?i)\b(?:this is an?|is an?|will be an?)\s+(?:\d+[- ]months?\s+)?(contract|contract-to-hire|temporary|part[- ]time|paid internship|internship)\b`)
+	descTermRE = regexp.MustCompile(`(?i)\b\d+[- ]months?\s+(contract|internship)\b`)
+)
+
+// ClassifyEmploymentType decides a job's employment type. The platform's own
+// label wins; otherwise the title and then the description are checked, and
+// a posting that names no type is taken to be full time. Boards such as
+// Workday report hours rather than engagement, so a "Full time" label still
+// yields to an intern or contract title.
+func ClassifyEmploymentType(platformLabel, title, description string) string {
+	platform := NormalizeEmploymentType(platformLabel)
+	switch {
+	case platform != "" && platform != FullTime:
+		return platform
+	case titleInternRE.MatchString(title):
+		return Intern
+	case titleContractRE.MatchString(title):
+		return Contract
+	case platform == FullTime:
+		return FullTime
+	case titlePartTimeRE.MatchString(title):
+		return PartTime
+	}
+	for _, re := range []*regexp.Regexp{descTypeRE, descTermRE} {
+		if m := re.FindStringSubmatch(description); m != nil {
+			return NormalizeEmploymentType(m[1])
+		}
+	}
+	return FullTime
+}
@@ -0,0 +1,47 @@
+package h1b
+
+import "testing"
+
+func TestClassifyEmploymentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		title    string
+		desc     string
+		want     string
+	}{
+		{"lever commitment", "Full-time", "Software Engineer", "", FullTime},
+		{"ashby intern", "Intern", "Software Engineer", "", Intern},
+		{"ashby part time", "PartTime", "Support Engineer", "", PartTime},
+		{"smartrecruiters contractor", "Contractor", "Data Engineer", "", Contract},
+		{"workday time type", "Full time", "Software Engineer Intern", "", Intern},
+		{"title internship", "", "Software Engineering Intern - Summer 2025", "", Intern},
+		{"title co-op", "", "Co-op, Platform Team", "", Intern},
+		{"title contract", "", "Backend Engineer (6 Month Contract)", "", Contract},
+		{"title part time", "", "Part-Time Tutor", "", PartTime},
+		{"description contract", "", "Backend Engineer", "This is a 6-month contract role with possible extension.", Contract},
+		{"description internship", "", "Software Engineer", "This is a paid internship for current students.", Intern},
+		{"former interns are not interns", "", "Software Engineer, New Grad", "Many of our engineers started as interns.", FullTime},
+		{"unknown platform label", "Hybrid", "Software Engineer", "", FullTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyEmploymentType(tt.platform, tt.title, tt.desc); got != tt.want {
+				t.Errorf("ClassifyEmploymentType(%q, %q, ...) = %q, want %q", tt.platform, tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmploymentType(t *testing.T) {
+	for in, want := range map[string]string{
+		"full-time": FullTime, "FULL_TIME": FullTime, "part-time": PartTime,
+		"internship": Intern, "contractor": Contract, "temp": Contract, "": "", "remote": "",
+		"FullTime": FullTime, "PartTime": PartTime, "Intern": Intern, "FIXED_TERM": Contract, "Contract_to_hire": Contract,
+		"International": "", "Internal": "", "Full time - Internal transfer": FullTime, "Template": "",
+	} {
+		if got := NormalizeEmploymentType(in); got != want {
+			t.Errorf("NormalizeEmploymentType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package h1b
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+// Restriction kinds stored on jobs.
+const (
+	// RestrictCitizenship means only US citizens are eligible.
+	RestrictCitizenship = "citizenship"
+	// RestrictGreenCard means only citizens and permanent residents are
+	// eligible.
+	RestrictGreenCard = "green_card"
+	// RestrictNoSponsorship means the employer will not sponsor a visa now
+	// or in the future.
+	RestrictNoSponsorship = "no_sponsorship"
+	// RestrictClearance means a security clearance is held or must be
+	// obtained; Detail gives the level when the posting names one.
+	RestrictClearance = "clearance"
+	// RestrictExportControl means ITAR or EAR rules limit the role to US
+	// persons; Detail is "ITAR", "EAR" or "ITAR/EAR".
+	RestrictExportControl = "export_control"
+)
+
+var (
+	citizenRE = regexp.MustCompile(`(?i)\b(?:must be (?:an? )?(?:u\.?s\.?|united states|american) citizens?|(?:u\.?s\.?|united states) citizens? only|only (?:u\.?s\.?|united states) citizens|(?:u\.?s\.?|united states) citizenship (?:is )?(?:required|mandatory)|requires? (?:u\.?s\.?|united states) citizenship|(?:must|need to) (?:have|hold|possess) (?:u\.?s\.?|united states) citizenship)`)
+	// residentRE widens a citizenship requirement to permanent residents:
+	// "must be a US citizen or permanent resident".
+	residentRE  = regexp.MustCompile(`(?i)^[\s,/]*(?:\(?or\)?|and(?:/or)?|/)\s+(?:an? )?(?:lawful |legal )?(?:u\.?s\.? )?(?:permanent residents?|green card holders?)`)
+	greenCardRE = regexp.MustCompile(`(?i)\b(?:(?:u\.?s\.? )?citizens? (?:or|and(?:/or)?|/) (?:lawful |legal )?(?:u\.?s\.? )?(?:permanent residents?|green card holders?) only|green card holders? only|must (?:have|hold|possess) (?:a )?green card|(?:only|must be) (?:u\.?s\.? )?(?:citizens? or )?(?:lawful )?permanent residents?)`)
+
+	noSponsorRE = regexp.MustCompile(`(?i)\b(?:(?:will not|won't|cannot|can't|can ?not|unable to|do not|does not|are not able to|is not able to|not able to)\s+(?:provide\s+|offer\s+|support\s+)?(?:(?:an?y?|h-?1b|visa|immigration|employment|work)\s+)*sponsor|without (?:the )?(?:need (?:for|of) |requiring )?(?:(?:current|future|now|or|in|the|any|visa|immigration|employment|h-?1b)\s+)*sponsorship|(?:visa |immigration )?sponsorship (?:is )?(?:not (?:available|provided|offered|possible)|unavailable)|\bno (?:visa |immigration |h-?1b )?sponsorship)`)
+
+	clearanceRE = regexp.MustCompile(`(?i)\b(?:((?:ts\s*/\s*sci|top[- ]secret(?:\s*/\s*sci)?|secret|public trust|dod|doe|q|active|current|security|government|federal)[\s/-]+)+clearances?\b|ts\s*/\s*sci\b|polygraph)`)
+	levelRE     = regexp.MustCompile(`(?i)ts\s*/\s*sci|top[- ]secret(?:\s*/\s*sci)?|secret|public trust`)
+
+	itarRE = regexp.MustCompile(`(?i)\bITAR\b|international traffic in arms`)
+	// EAR is only matched in capitals; "ear" is an ordinary word.
+	earRE          = regexp.MustCompile(`\bEAR\b|(?i:export administration regulations)`)
+	exportRE       = regexp.MustCompile(`(?i)subject to (?:u\.?s\.? )?export controls?|export[- ]controlled|deemed exports?`)
+	usPersonRE     = regexp.MustCompile(`(?i)\bu\.?s\.? persons?\b`)
+	mustUSPersonRE = regexp.MustCompile(`(?i)(?:must be|only) (?:an? )?u\.?s\.? persons?\b|u\.?s\.? person status`)
+
+	// negationRE, in the text just before a match, means the requirement is
+	// being waived: "no clearance required", "you don't need to be a US
+	// citizen".
+	negationRE = regexp.MustCompile(`(?i)(?:\bno\b|\bnot\b|n't\b|\bnever\b)[^.;]{0,30}$`)
+	// waivedRE, just after a match, does the same: "clearance is not
+	// required".
+	waivedRE = regexp.MustCompile(`(?i)^\s*(?:is\s+|are\s+)?(?:not (?:required|needed|necessary)|optional)`)
+)
+
+// DetectRestrictions finds eligibility requirements that shut out candidates
+// who need a visa: citizenship or permanent residency, an unwillingness to
+// sponsor, security clearances and export controls. It returns an empty,
+// non-nil slice when the posting states none.
+func DetectRestrictions(title, description string) []database.Restriction {
+	text := title + "\n" + description
+	found := []database.Restriction{}
+	add := func(kind, detail, evidence string) {
+		for _, r := range found {
+			if r.Kind == kind {
+				return
+			}
+		}
+		found = append(found, database.Restriction{Kind: kind, Detail: detail, Evidence: strings.TrimSpace(evidence)})
+	}
+
+	if loc := firstAffirmed(citizenRE, text); loc != nil {
+		evidence := text[loc[0]:loc[1]]
+		if m := residentRE.FindString(text[loc[1]:]); m != "" {
+			add(RestrictGreenCard, "", evidence+m)
+		} else {
+			add(RestrictCitizenship, "", evidence)
+		}
+	}
+	if loc := firstAffirmed(greenCardRE, text); loc != nil {
+		add(RestrictGreenCard, "", text[loc[0]:loc[1]])
+	}
+	if loc := noSponsorRE.FindStringIndex(text); loc != nil {
+		add(RestrictNoSponsorship, "", text[loc[0]:loc[1]])
+	}
+	if loc := firstAffirmed(clearanceRE, text); loc != nil {
+		add(RestrictClearance, clearanceLevel(text[loc[0]:loc[1]]), text[loc[0]:loc[1]])
+	}
+
+	itar, ear := firstAffirmed(itarRE, text), firstAffirmed(earRE, text)
+	usPerson := usPersonRE.MatchString(text)
+	exported := firstAffirmed(exportRE, text)
+	switch {
+	case itar != nil && ear != nil:
+		add(RestrictExportControl, "ITAR/EAR", text[itar[0]:itar[1]])
+	case itar != nil:
+		add(RestrictExportControl, "ITAR", text[itar[0]:itar[1]])
+	case ear != nil && (usPerson || exported != nil):
+		add(RestrictExportControl, "EAR", text[ear[0]:ear[1]])
+	case exported != nil && usPerson:
+		add(RestrictExportControl, "", text[exported[0]:exported[1]])
+	default:
+		if loc := firstAffirmed(mustUSPersonRE, text); loc != nil {
+			add(RestrictExportControl, "", text[loc[0]:loc[1]])
+		}
+	}
+	return found
+}
+
+// firstAffirmed returns the first match of re that is not negated.
+func firstAffirmed(re *regexp.Regexp, text string) []int {
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		start := loc[0] - 40
+		if start < 0 {
+			start = 0
+		}
+		if !negationRE.MatchString(text[start:loc[0]]) && !waivedRE.MatchString(text[loc[1]:]) {
+			return loc
+		}
+	}
+	return nil
+}
+
+func clearanceLevel(match string) string {
+	level := strings.ToLower(levelRE.FindString(match))
+	switch {
+	case strings.Contains(level, "sci"):
+		return "ts_sci"
+	case strings.HasPrefix(level, "top"):
+		return "top_secret"
+	case level == "secret":
+		return "secret"
+	case level == "public trust":
+		return "public_trust"
+	}
+	return ""
+}
+
+// RestrictionsOf returns a job's stored restrictions, detecting them from
+// its text when it was stored before detection existed.
+func RestrictionsOf(job database.Job) []database.Restriction {
+	if job.Restrictions != nil {
+		return job.Restrictions
+	}
+	description := ""
+	if job.Description != nil {
+		description = *job.Description
+	}
+	return DetectRestrictions(job.Title, description)
+}
+
+// DescribeRestriction renders a restriction for display, e.g. "Security
+// clearance (top secret)".
+func DescribeRestriction(r database.Restriction) string {
+	var s string
+	switch r.Kind {
+	case RestrictCitizenship:
+		s = "US citizenship required"
+	case RestrictGreenCard:
+		s = "US citizens or permanent residents only"
+	case RestrictNoSponsorship:
+		s = "No visa sponsorship"
+	case RestrictClearance:
+		s = "Security clearance"
+	case RestrictExportControl:
+		s = "Export-controlled (US persons only)"
+	default:
+		s = r.Kind
+	}
+	if r.Detail != "" {
+		s += " (" + strings.ReplaceAll(r.Detail, "_", " ") + ")"
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package h1b
+
+import (
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+func TestDetectRestrictions(t *testing.T) {
+	tests := []struct {
+		name       string
+		title      string
+		desc       string
+		wantKind   string
+		wantDetail string
+	}{
+		{"citizenship", "Software Engineer", "Applicants must be a U.S. citizen due to contract requirements.", RestrictCitizenship, ""},
+		{"citizens only", "Software Engineer", "This role is open to US citizens only.", RestrictCitizenship, ""},
+		{"citizen or resident", "Software Engineer", "Must be a US citizen or permanent resident.", RestrictGreenCard, ""},
+		{"green card holders", "Software Engineer", "Green card holders only, please.", RestrictGreenCard, ""},
+		{"no sponsorship", "Software Engineer", "We are unable to sponsor visas for this position.", RestrictNoSponsorship, ""},
+		{"now or in the future", "Software Engineer", "Candidates must be authorized to work in the US without sponsorship now or in the future.", RestrictNoSponsorship, ""},
+		{"top secret", "Software Engineer", "Active Top Secret clearance required.", RestrictClearance, "top_secret"},
+		{"ts/sci in title", "Software Engineer (TS/SCI)", "", RestrictClearance, "ts_sci"},
+		{"obtain secret", "Software Engineer", "Ability to obtain a Secret clearance.", RestrictClearance, "secret"},
+		{"itar", "Software Engineer", "This position requires access to ITAR-controlled technical data.", RestrictExportControl, "ITAR"},
+		{"ear us person", "Software Engineer", "Work is subject to the EAR; applicants must be a U.S. person.", RestrictExportControl, "EAR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectRestrictions(tt.title, tt.desc)
+			for _, r := range got {
+				if r.Kind == tt.wantKind {
+					if r.Detail != tt.wantDetail {
+						t.Errorf("detail = %q, want %q", r.Detail, tt.wantDetail)
+					}
+					if r.Evidence == "" {
+						t.Error("expected evidence")
+					}
+					return
+				}
+			}
+			t.Errorf("DetectRestrictions() = %+v, want a %s restriction", got, tt.wantKind)
+		})
+	}
+}
+
+func TestDetectRestrictions_None(t *testing.T) {
+	for _, desc := range []string{
+		"We sponsor H1B visas and welcome international applicants.",
+		"No security clearance required.",
+		"A security clearance is not required for this role.",
+		"You don't need to be a US citizen to apply.",
+		"Lend us your ear: we value feedback.",
+		"Great benefits and a friendly team.",
+	} {
+		if got := DetectRestrictions("Software Engineer", desc); len(got) != 0 {
+			t.Errorf("DetectRestrictions(%q) = %+v, want none", desc, got)
+		}
+	}
+	if got := DetectRestrictions("", ""); got == nil {
+		t.Error("expected an empty, non-nil slice")
+	}
+}
+
+func TestScoreH1B_Restrictions(t *testing.T) {
+	profile := database.Profile{VisaRequired: true}
+	rate := 95.0
+	sponsor := database.Company{SponsorsH1b: true, H1bApprovalRate: &rate}
+
+	clearance := database.Job{Restrictions: []database.Restriction{{Kind: RestrictClearance, Detail: "secret"}}}
+	if adj := ScoreH1B(clearance, sponsor, profile); adj.Delta > -80 {
+		t.Errorf("clearance Delta = %.0f, want a hard penalty", adj.Delta)
+	}
+
+	trust := database.Job{Restrictions: []database.Restriction{{Kind: RestrictClearance, Detail: "public_trust"}}}
+	if adj := ScoreH1B(trust, database.Company{}, profile); adj.Delta != -30 {
+		t.Errorf("public trust Delta = %.0f, want -30", adj.Delta)
+	}
+
+	// The negative visa sentiment comes from the same text, so it is not
+	// counted again on top of the restriction.
+	negative := "negative"
+	noSponsor := database.Job{VisaSentiment: &negative, Restrictions: []database.Restriction{{Kind: RestrictNoSponsorship}}}
+	if adj := ScoreH1B(noSponsor, database.Company{}, profile); adj.Delta != -100 {
+		t.Errorf("no sponsorship Delta = %.0f, want -100", adj.Delta)
+	}
+
+	if adj := ScoreH1B(clearance, sponsor, database.Profile{}); adj.Delta != 0 {
+		t.Errorf("visa not required: Delta = %.0f, want 0", adj.Delta)
+	}
+}
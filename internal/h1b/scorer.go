@@ -43,6 +43,24 @@ func ScoreH1B(job database.Job, company database.Company, profile database.Profi
 		sentiment = *job.VisaSentiment
 	}
 
+	// A stated restriction outweighs everything else: only the harshest one
+	// counts, and it replaces the coarser negative sentiment read from the
+	// same text.
+	var penalty float64
+	var restricted string
+	for _, r := range RestrictionsOf(job) {
+		if p := restrictionPenalty(r); p > penalty {
+			penalty, restricted = p, DescribeRestriction(r)
+		}
+	}
+	if penalty > 0 {
+		delta -= penalty
+		reasons = append(reasons, restricted)
+		if sentiment == "negative" {
+			sentiment = ""
+		}
+	}
+
 	switch sentiment {
 	case "positive":
 		delta += 10
@@ -64,4 +82,14 @@ func ScoreH1B(job database.Job, company database.Company, profile database.Profi
 		Delta: delta,
 		Reason: reason,
 	}
-}
\ No newline at end of file
+}
+
+// restrictionPenalty is how far a restriction pushes a visa-dependent
+// candidate's score down. Most rule them out entirely; a public trust
+// position is open to some non-citizens.
+func restrictionPenalty(r database.Restriction) float64 {
+	if r.Kind == RestrictClearance && r.Detail == "public_trust" {
+		return 30
+	}
+	return 100
+}
@@ -0,0 +1,207 @@
+// Package htmltext converts job description HTML into plain text that keeps
+// the posting's structure: headings on their own lines, one bullet per list
+// item and blank lines between paragraphs.
+package htmltext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	tagRE        = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9]*(?:\s[^<>]*)?/?>`)
+	escapedTagRE = regexp.MustCompile(`&lt;/?[a-zA-Z][a-zA-Z0-9]*(?:\s|/|&gt;)`)
+	blankLinesRE = regexp.MustCompile(`\n{3,}`)
+)
+
+// IsHTML reports whether s contains markup, escaped or not.
+func IsHTML(s string) bool {
+	return tagRE.MatchString(s) || escapedTagRE.MatchString(s)
+}
+
+// ToText normalizes a description to plain text. HTML, including HTML that
+// arrives entity-escaped as Greenhouse sends it, is rendered with headings
+// and list items on their own lines ("- item", or "1. item" for ordered
+// lists). Text without markup only has its whitespace tidied, so ToText is
+// safe to apply to any description and to its own output.
+func ToText(s string) string {
+	if escapedTagRE.MatchString(s) {
+		s = html.UnescapeString(s)
+	}
+	if !tagRE.MatchString(s) {
+		return tidy(html.UnescapeString(s))
+	}
+
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(s), body)
+	if err != nil {
+		return tidy(tagRE.ReplaceAllString(s, "\n"))
+	}
+	w := &writer{}
+	for _, n := range nodes {
+		w.node(n)
+	}
+	return tidy(w.b.String())
+}
+
+// tidy trims trailing spaces, drops runs of blank lines and trims the ends.
+func tidy(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	s = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLinesRE.ReplaceAllString(s, "\n\n"))
+}
+
+type list struct {
+	ordered bool
+	n       int
+}
+
+type writer struct {
+	b     strings.Builder
+	lists []list
+	pre   int
+	// space records collapsed whitespace waiting to be written before the
+	// next word on the line.
+	space bool
+}
+
+// atLineStart reports whether nothing has been written on the current line.
+func (w *writer) atLineStart() bool {
+	s := w.b.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+// spaced reports whether a word written now needs no separating space.
+func (w *writer) spaced() bool {
+	s := w.b.String()
+	return s == "" || strings.HasSuffix(s, "\n") || strings.HasSuffix(s, " ")
+}
+
+// breakLines ends the current line and, for n == 2, leaves a blank line.
+func (w *writer) breakLines(n int) {
+	w.space = false
+	s := w.b.String()
+	if s == "" {
+		return
+	}
+	have := len(s) - len(strings.TrimRight(s, "\n"))
+	for ; have < n; have++ {
+		w.b.WriteByte('\n')
+	}
+}
+
+func (w *writer) text(s string) {
+	if w.pre > 0 {
+		w.b.WriteString(s)
+		return
+	}
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	if s != "" && strings.TrimLeft(s, " \t\r\n") != s {
+		w.space = true
+	}
+	words := strings.Fields(s)
+	for i, word := range words {
+		if (i > 0 || w.space) && !w.spaced() {
+			w.b.WriteByte(' ')
+		}
+		w.b.WriteString(word)
+		w.space = false
+	}
+	if len(words) > 0 && strings.TrimRight(s, " \t\r\n") != s {
+		w.space = true
+	}
+}
+
+func (w *writer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		w.children(n)
+		return
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head, atom.Noscript, atom.Template:
+	case atom.Br:
+		w.b.WriteByte('\n')
+		w.space = false
+	case atom.Hr:
+		w.breakLines(2)
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		w.breakLines(2)
+		w.children(n)
+		w.breakLines(1)
+	case atom.Ul, atom.Ol:
+		w.breakLines(1)
+		w.lists = append(w.lists, list{ordered: n.DataAtom == atom.Ol})
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		w.breakLines(1)
+	case atom.Li:
+		w.breakLines(1)
+		w.b.WriteString(w.bullet())
+		w.children(n)
+		w.breakLines(1)
+	case atom.Pre:
+		w.breakLines(2)
+		w.pre++
+		w.children(n)
+		w.pre--
+		w.breakLines(2)
+	case atom.Tr, atom.Dt, atom.Dd:
+		w.breakLines(1)
+		w.children(n)
+		w.breakLines(1)
+	case atom.Td, atom.Th:
+		w.space = true
+		w.children(n)
+		w.space = true
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer,
+		atom.Blockquote, atom.Table, atom.Dl, atom.Figure, atom.Main, atom.Aside, atom.Nav:
+		if len(w.lists) > 0 && !w.atLineStart() && n.DataAtom == atom.P {
+			// A <p> inside a list item continues the item.
+			w.children(n)
+			return
+		}
+		w.breakLines(2)
+		w.children(n)
+		w.breakLines(2)
+	default:
+		w.children(n)
+	}
+}
+
+func (w *writer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+// bullet returns the marker for the next item of the innermost list,
+// indented by nesting depth.
+func (w *writer) bullet() string {
+	if len(w.lists) == 0 {
+		return "- "
+	}
+	indent := strings.Repeat("  ", len(w.lists)-1)
+	l := &w.lists[len(w.lists)-1]
+	if l.ordered {
+		l.n++
+		return indent + strconv.Itoa(l.n) + ". "
+	}
+	return indent + "- "
+}
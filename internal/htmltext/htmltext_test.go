@@ -0,0 +1,61 @@
+package htmltext
+
+import "testing"
+
+func TestToText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "headings and bullets",
+			in:   `<p>We build <b>payments</b> infrastructure.</p><h3>Requirements</h3><ul><li>Go</li><li>PostgreSQL &amp; Redis</li></ul><h3>Nice to have</h3><ul><li><p>Kubernetes</p></li></ul>`,
+			want: "We build payments infrastructure.\n\nRequirements\n- Go\n- PostgreSQL & Redis\n\nNice to have\n- Kubernetes",
+		},
+		{
+			name: "greenhouse escaped html",
+			in:   `&lt;div&gt;&lt;strong&gt;Qualifications&lt;/strong&gt;&lt;/div&gt;&lt;ul&gt;&lt;li&gt;3+ years of Python&lt;/li&gt;&lt;/ul&gt;`,
+			want: "Qualifications\n\n- 3+ years of Python",
+		},
+		{
+			name: "ordered and nested lists",
+			in:   "<ol><li>Design</li><li>Build<ul><li>APIs</li></ul></li></ol>",
+			want: "1. Design\n2. Build\n  - APIs",
+		},
+		{
+			name: "line breaks and whitespace",
+			in:   "<div>Location:\n   Remote<br>Team:&nbsp;Platform</div><script>track()</script>",
+			want: "Location: Remote\nTeam: Platform",
+		},
+		{
+			name: "plain text is only tidied",
+			in:   "Requirements:  \n- Go\n\n\n\nBenefits &amp; perks",
+			want: "Requirements:\n- Go\n\nBenefits & perks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToText(tt.in)
+			if got != tt.want {
+				t.Errorf("ToText() =\n%q\nwant\n%q", got, tt.want)
+			}
+			if again := ToText(got); again != got {
+				t.Errorf("ToText is not idempotent: %q -> %q", got, again)
+			}
+		})
+	}
+}
+
+func TestIsHTML(t *testing.T) {
+	for in, want := range map[string]bool{
+		"<p>Hi</p>":             true,
+		"&lt;p&gt;Hi&lt;/p&gt;": true,
+		"C++ & Go, 5 < 10":      false,
+		"Plain description":     false,
+	} {
+		if got := IsHTML(in); got != want {
+			t.Errorf("IsHTML(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
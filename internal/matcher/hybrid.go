@@ -10,14 +10,25 @@ type HybridMatcher struct {
 	threshold float64
 }
 
-func NewHybridMatcher(apiKey string, threshold float64) *HybridMatcher {
+func NewHybridMatcher(provider Provider, threshold float64) *HybridMatcher {
 	return &HybridMatcher{
 		keyword:   NewKeywordMatcher(),
-		llm:       NewLLMMatcher(apiKey),
+		llm:       NewLLMMatcher(provider),
 		threshold: threshold,
 	}
 }
 
+// Track caches the LLM stage's replies and holds it to budget; see
+// LLMMatcher.Track.
+func (h *HybridMatcher) Track(db *database.DB, runID string, budget Budget) {
+	h.llm.Track(db, runID, budget)
+}
+
+// Usage reports the LLM stage's usage.
+func (h *HybridMatcher) Usage() Usage {
+	return h.llm.Usage()
+}
+
 func (h *HybridMatcher) Match(job database.Job, profile database.Profile) MatchResult {
 	keywordResult := h.keyword.Match(job, profile)
 
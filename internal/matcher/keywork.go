@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/geo"
 )
 
 type KeywordMatcher struct {}
@@ -84,20 +85,11 @@ func (k *KeywordMatcher) Match(job database.Job, profile database.Profile) Match
 	if len(profileLocations) > 0 {
 		jobLocation := ""
 		if job.Location != nil {
-			jobLocation = strings.ToLower(*job.Location)
+			jobLocation = *job.Location
 		}
-		for _, loc := range profileLocations {
-			locLower := strings.ToLower(loc)
-			if locLower == "remote" && job.Remote {
-				score += 20.0
-				reasons = append(reasons, "Location: remote")
-				break
-			}
-			if strings.Contains(jobLocation, locLower) {
-				score += 20.0
-				reasons = append(reasons, fmt.Sprintf("Location: %s", loc))
-				break
-			}
+		if term, ok := geo.ParseQuery(profileLocations).Match(geo.PlacesOf(job), jobLocation); ok {
+			score += 20.0
+			reasons = append(reasons, fmt.Sprintf("Location: %s", term))
 		}
 	}
 
@@ -1,55 +1,23 @@
 package matcher
 
 import (
-	"bytes"
-	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"strings"
-	"time"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
 )
 
-type LLMMatcher struct {
-	apiKey string
-	model string
-	client *http.Client
-}
-
-func NewLLMMatcher(apiKey string) *LLMMatcher {
-	return &LLMMatcher{
-		apiKey: apiKey,
-		model: "claude-sonnet-4-5-20250929",
-		client: &http.Client{Timeout: 30 * time.Second},
-	}
-}
-
-type claudeRequest struct {
-	Model		string			`json:"model"`
-	MaxTokens 	int				`json:"max_tokens"`
-	Messages 	[]claudeMessage `json:"messages"`
-}
-
-type claudeMessage struct {
-	Role 	string `json:"role"`
-	Content string `json:"content"`
-}
-
-type claudeResponse struct {
-	Content	[]claudeContent	`json:"content"`
-	Error	*claudeError		`json:"error,omitempty"`
-}
+// MatchPromptVersion identifies the match prompt below. Bump it when the
+// prompt changes so replies cached for the old prompt are not reused.
+const MatchPromptVersion = "match-v1"
 
-type claudeContent struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+type LLMMatcher struct {
+	provider Provider
+	// llmTracker caches replies and budgets requests once Track is called.
+	llmTracker
 }
 
-type claudeError struct {
-	Type 	string `json:"type"`
-	Message string `json:"message"`
+func NewLLMMatcher(provider Provider) *LLMMatcher {
+	return &LLMMatcher{provider: provider}
 }
 
 type llmMatchResponse struct {
@@ -58,19 +26,16 @@ type llmMatchResponse struct {
 }
 
 func (l *LLMMatcher) Match(job database.Job, profile database.Profile) MatchResult {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	result, err := l.callAPI(ctx, job, profile)
+	result, err := l.callAPI(job, profile)
 	if err != nil {
-		// Fallback to keyword matcher on API failure
+		// Fallback to keyword matcher on API failure or a spent budget
 		return NewKeywordMatcher().Match(job, profile)
 	}
 
 	return result
 }
 
-func (l *LLMMatcher) callAPI(ctx context.Context, job database.Job, profile database.Profile) (MatchResult, error) {
+func (l *LLMMatcher) callAPI(job database.Job, profile database.Profile) (MatchResult, error) {
 	// Build the prompt
 	description := ""
 	if job.Description != nil {
@@ -124,54 +89,9 @@ func (l *LLMMatcher) callAPI(ctx context.Context, job database.Job, profile data
 		description,
 	)
 
-	reqBody := claudeRequest{
-		Model:     l.model,
-		MaxTokens: 150,
-		Messages: []claudeMessage{
-			{Role: "user", Content: prompt},
-		},
-	}
-
-	bodyBytes, err := json.Marshal(reqBody)
-	if err != nil {
-		return MatchResult{}, fmt.Errorf("marshaling request: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(bodyBytes))
-	if err != nil {
-		return MatchResult{}, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", l.apiKey)
-	req.Header.Set("anthropic-version", "2023-06-01")
-
-	resp, err := l.client.Do(req)
-	if err != nil {
-		return MatchResult{}, fmt.Errorf("calling Claude API: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var claudeResp claudeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&claudeResp); err != nil {
-		return MatchResult{}, fmt.Errorf("decoding response: %w", err)
-	}
-
-	if claudeResp.Error != nil {
-		return MatchResult{}, fmt.Errorf("API error: %s", claudeResp.Error.Message)
-	}
-
-	if len(claudeResp.Content) == 0 {
-		return MatchResult{}, fmt.Errorf("empty response from API")
-	}
-
-	// Parse the JSON response from Claude
-	text := claudeResp.Content[0].Text
-	text = strings.TrimSpace(text)
-
 	var matchResp llmMatchResponse
-	if err := json.Unmarshal([]byte(text), &matchResp); err != nil {
-		return MatchResult{}, fmt.Errorf("parsing match response: %w (raw: %s)", err, text)
+	if err := l.complete(l.provider, MatchPromptVersion, prompt, 150, &matchResp); err != nil {
+		return MatchResult{}, err
 	}
 
 	// Clamp score
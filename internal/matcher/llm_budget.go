@@ -0,0 +1,175 @@
+package matcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/spf13/viper"
+)
+
+// ErrBudgetExhausted is returned instead of calling the LLM once the
+// token budget is spent.
+var ErrBudgetExhausted = errors.New("LLM token budget exhausted")
+
+// Budget caps the tokens, input plus output, LLM scoring may spend. Zero
+// means no limit. The budget is checked before each request, so a run can
+// overshoot it by at most one request. Everything tracked under the same run
+// ID shares the per-run budget.
+type Budget struct {
+	PerRun int `json:"per_run"`
+	PerDay int `json:"per_day"`
+}
+
+// LoadBudget reads llm.budget.per_run and llm.budget.per_day from config.
+func LoadBudget() Budget {
+	return Budget{
+		PerRun: viper.GetInt("llm.budget.per_run"),
+		PerDay: viper.GetInt("llm.budget.per_day"),
+	}
+}
+
+// Usage is what LLM scoring did during one run.
+type Usage struct {
+	Requests     int `json:"requests"`
+	CacheHits    int `json:"cache_hits"`
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	// OverBudget counts jobs scored without the LLM because the budget
+	// was spent.
+	OverBudget int `json:"over_budget"`
+	// Exhausted says which budget ran out, if one did.
+	Exhausted string `json:"exhausted,omitempty"`
+}
+
+// Tokens is the run's input and output tokens together.
+func (u Usage) Tokens() int { return u.InputTokens + u.OutputTokens }
+
+// String summarizes the usage, e.g.
+// "12 requests, 30 cached, 14200 tokens; daily budget of 50000 tokens exhausted, 8 jobs scored without the LLM".
+func (u Usage) String() string {
+	s := fmt.Sprintf("%d requests, %d cached, %d tokens", u.Requests, u.CacheHits, u.Tokens())
+	if u.Exhausted != "" {
+		s += fmt.Sprintf("; %s exhausted, %d jobs scored without the LLM", u.Exhausted, u.OverBudget)
+	}
+	return s
+}
+
+// llmTracker caches an LLM caller's replies, holds its requests to a token
+// budget and logs their usage, once Track is called.
+type llmTracker struct {
+	store  *database.DB
+	runID  string
+	budget Budget
+	usage  Usage
+	now    func() time.Time
+}
+
+// Track caches replies in db and enforces budget, logging usage under
+// runID. Without it every prompt is sent to the LLM.
+func (t *llmTracker) Track(db *database.DB, runID string, budget Budget) {
+	t.store, t.runID, t.budget = db, runID, budget
+	if t.now == nil {
+		t.now = time.Now
+	}
+}
+
+// Usage reports what has been sent and answered so far.
+func (t *llmTracker) Usage() Usage {
+	return t.usage
+}
+
+// spent checks the budget before a request.
+func (t *llmTracker) spent() error {
+	if t.usage.Exhausted != "" {
+		return ErrBudgetExhausted
+	}
+	if t.budget.PerRun > 0 {
+		run, err := t.store.LLMTokensForRun(t.runID)
+		if err != nil {
+			return err
+		}
+		if run >= t.budget.PerRun {
+			t.usage.Exhausted = fmt.Sprintf("per-run budget of %d tokens", t.budget.PerRun)
+			return ErrBudgetExhausted
+		}
+	}
+	if t.budget.PerDay > 0 {
+		today, err := t.store.LLMTokensSince(startOfDay(t.now()))
+		if err != nil {
+			return err
+		}
+		if today >= t.budget.PerDay {
+			t.usage.Exhausted = fmt.Sprintf("daily budget of %d tokens", t.budget.PerDay)
+			return ErrBudgetExhausted
+		}
+	}
+	return nil
+}
+
+// complete answers prompt from the cache, or from provider within the
+// budget, and decodes the JSON reply into out. Only replies that decode are
+// cached.
+func (t *llmTracker) complete(provider Provider, version, prompt string, maxTokens int, out interface{}) error {
+	var key string
+	if t.store != nil {
+		key = cacheKey(version, provider, prompt)
+		if cached, ok, err := t.store.GetLLMCache(key); err == nil && ok && parseJSONReply(cached, out) == nil {
+			t.usage.CacheHits++
+			_ = t.store.RecordLLMUsage(database.LLMUsage{RunID: t.runID, Provider: provider.Name(), Model: provider.Model(), Cached: true})
+			return nil
+		}
+		if err := t.spent(); err != nil {
+			t.usage.OverBudget++
+			return err
+		}
+	}
+
+	// The provider bounds the request with its configured timeout.
+	completion, err := provider.Complete(context.Background(), CompletionRequest{Prompt: prompt, MaxTokens: maxTokens})
+	if err != nil {
+		return err
+	}
+	// Tokens count against the budget even if the reply is unusable.
+	t.usage.Requests++
+	t.usage.InputTokens += completion.InputTokens
+	t.usage.OutputTokens += completion.OutputTokens
+	if t.store != nil {
+		_ = t.store.RecordLLMUsage(database.LLMUsage{
+			RunID:        t.runID,
+			Provider:     provider.Name(),
+			Model:        provider.Model(),
+			InputTokens:  completion.InputTokens,
+			OutputTokens: completion.OutputTokens,
+		})
+	}
+
+	if err := parseJSONReply(completion.Text, out); err != nil {
+		return err
+	}
+	if t.store != nil {
+		_ = t.store.PutLLMCache(key, provider.Name(), provider.Model(), completion.Text)
+	}
+	return nil
+}
+
+// startOfDay is local midnight on t's day, when the daily budget resets.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// cacheKey identifies an LLM reply by the prompt version, the model asked
+// and the prompt itself, which carries the job content and profile.
+func cacheKey(version string, p Provider, prompt string) string {
+	h := sha256.New()
+	for _, part := range []string{version, p.Name(), p.Model(), prompt} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,160 @@
+package matcher
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+// countingProvider answers every prompt with the same score and counts
+// the requests it gets.
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Name() string  { return "fake" }
+func (p *countingProvider) Model() string { return "fake-1" }
+
+func (p *countingProvider) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	p.calls++
+	return Completion{Text: `{"score": 80, "matched_skills": ["Go"], "reason": "fits"}`, InputTokens: 400, OutputTokens: 100}, nil
+}
+
+func setupTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.New(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	if err := db.Migrate(filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestLLMSkillScorerCachesReplies(t *testing.T) {
+	db := setupTestDB(t)
+	provider := &countingProvider{}
+	profile := database.Profile{Skills: `["Go"]`}
+	job := database.Job{ID: "a", Description: strPtr("Requirements: Go")}
+
+	first := NewLLMSkillScorer(provider)
+	first.Track(db, "run-1", Budget{})
+	if _, err := first.Score(job, profile); err != nil {
+		t.Fatal(err)
+	}
+
+	// A later run re-scoring the same job and profile reuses the reply.
+	second := NewLLMSkillScorer(provider)
+	second.Track(db, "run-2", Budget{})
+	got, err := second.Score(job, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 1 || got.Score != 80 {
+		t.Errorf("calls = %d, score = %v; want 1 call and the cached score 80", provider.calls, got.Score)
+	}
+	if u := second.Usage(); u.CacheHits != 1 || u.Requests != 0 {
+		t.Errorf("usage = %+v, want one cache hit", u)
+	}
+
+	// A changed description is a different prompt.
+	job.Description = strPtr("Requirements: Go, Rust")
+	if _, err := second.Score(job, profile); err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("calls = %d, want changed job sent to the LLM", provider.calls)
+	}
+}
+
+func TestLLMSkillScorerBudget(t *testing.T) {
+	db := setupTestDB(t)
+	profile := database.Profile{Skills: `["Go"]`}
+	jobs := []database.Job{
+		{ID: "a", Description: strPtr("Go")},
+		{ID: "b", Description: strPtr("Rust")},
+		{ID: "c", Description: strPtr("Python")},
+	}
+
+	provider := &countingProvider{}
+	s := NewLLMSkillScorer(provider)
+	s.Track(db, "run-1", Budget{PerRun: 1000})
+	var exhausted int
+	for _, j := range jobs {
+		if _, err := s.Score(j, profile); errors.Is(err, ErrBudgetExhausted) {
+			exhausted++
+		}
+	}
+	// Two requests of 500 tokens each spend the per-run budget.
+	if provider.calls != 2 || exhausted != 1 {
+		t.Errorf("calls = %d, exhausted = %d; want 2 and 1", provider.calls, exhausted)
+	}
+	if u := s.Usage(); u.Tokens() != 1000 || u.OverBudget != 1 || u.Exhausted == "" {
+		t.Errorf("usage = %+v", u)
+	}
+
+	// The daily budget counts earlier runs.
+	s = NewLLMSkillScorer(provider)
+	s.Track(db, "run-2", Budget{PerDay: 1000})
+	if _, err := s.Score(jobs[2], profile); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("err = %v, want daily budget exhausted", err)
+	}
+}
+
+func TestPipelineFallsBackWhenBudgetSpent(t *testing.T) {
+	db := setupTestDB(t)
+	p := &Pipeline{keyword: NewSkillScorer(), llm: NewLLMSkillScorer(&countingProvider{}), mode: ModeLLM}
+	p.llm.Track(db, "run-1", Budget{PerRun: 1})
+
+	job := database.Job{ID: "a", Description: strPtr("Requirements: Go, Kubernetes")}
+	profile := database.Profile{Skills: `["Go"]`}
+	p.Score(job, profile)
+	got := p.Score(database.Job{ID: "b", Description: strPtr("Requirements: Go, Rust")}, profile)
+	want := NewSkillScorer().Score(database.Job{ID: "b", Description: strPtr("Requirements: Go, Rust")}, profile)
+	if got.Score != want.Score || got.Reason != want.Reason {
+		t.Errorf("over budget got %+v, want keyword result %+v", got, want)
+	}
+}
+
+func TestLLMMatcherSharesCacheAndRunBudget(t *testing.T) {
+	db := setupTestDB(t)
+	provider := &countingProvider{}
+	profile := database.Profile{Skills: `["Go"]`}
+	jobs := []database.Job{
+		{ID: "a", Title: "Backend Engineer", Description: strPtr("Go")},
+		{ID: "b", Title: "Backend Engineer", Description: strPtr("Rust")},
+	}
+
+	// A skill scorer and a matcher in the same run spend one budget.
+	scorer := NewLLMSkillScorer(provider)
+	scorer.Track(db, "run-1", Budget{PerRun: 1000})
+	m := NewLLMMatcher(provider)
+	m.Track(db, "run-1", Budget{PerRun: 1000})
+
+	if _, err := scorer.Score(jobs[0], profile); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Match(jobs[0], profile); got.Score != 80 {
+		t.Errorf("match = %+v, want the LLM's score", got)
+	}
+	// Asked again, the matcher answers from the cache.
+	if got := m.Match(jobs[0], profile); got.Score != 80 || provider.calls != 2 {
+		t.Errorf("match = %+v after %d calls, want the cached score without a call", got, provider.calls)
+	}
+
+	got := m.Match(jobs[1], profile)
+	if want := NewKeywordMatcher().Match(jobs[1], profile); provider.calls != 2 || got != want {
+		t.Errorf("over budget: %d calls, match %+v; want keyword result %+v", provider.calls, got, want)
+	}
+	if u := m.Usage(); u.Requests != 1 || u.CacheHits != 1 || u.OverBudget != 1 {
+		t.Errorf("usage = %+v", u)
+	}
+	if n, _ := db.LLMTokensForRun("run-1"); n != 1000 {
+		t.Errorf("run tokens = %d, want 1000 logged", n)
+	}
+}
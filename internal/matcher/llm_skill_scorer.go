@@ -1,29 +1,23 @@
 package matcher
 
 import (
-	"bytes"
-	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"strings"
-	"time"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
 )
 
+// SkillPromptVersion identifies the scoring prompt below. Bump it when the
+// prompt changes so replies cached for the old prompt are not reused.
+const SkillPromptVersion = "skill-v1"
+
 type LLMSkillScorer struct {
-	apiKey string
-	model  string
-	client *http.Client
+	provider Provider
+	// llmTracker caches replies and budgets requests once Track is called.
+	llmTracker
 }
 
-func NewLLMSkillScorer(apiKey string) *LLMSkillScorer {
-	return &LLMSkillScorer{
-		apiKey: apiKey,
-		model: "claude-haiku-4-5-20251001",
-		client: &http.Client{Timeout: 30 * time.Second},
-	}
+func NewLLMSkillScorer(provider Provider) *LLMSkillScorer {
+	return &LLMSkillScorer{provider: provider}
 }
 
 type llmSkillResponse struct {
@@ -34,9 +28,6 @@ type llmSkillResponse struct {
 }
 
 func (l *LLMSkillScorer) Score(job database.Job, profile database.Profile) (SkillScoreResult, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-    defer cancel()
-
     description := ""
     if job.Description != nil {
         description = *job.Description
@@ -70,52 +61,9 @@ Respond with ONLY valid JSON, no explanation outside the JSON:
         description,
     )
 
-    reqBody := claudeRequest{
-        Model:     l.model,
-        MaxTokens: 300,
-        Messages:  []claudeMessage{{Role: "user", Content: prompt}},
-    }
-
-    bodyBytes, err := json.Marshal(reqBody)
-    if err != nil {
-        return SkillScoreResult{}, fmt.Errorf("marshaling request: %w", err)
-    }
-
-    req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(bodyBytes))
-    if err != nil {
-        return SkillScoreResult{}, fmt.Errorf("creating request: %w", err)
-    }
-    req.Header.Set("Content-Type", "application/json")
-    req.Header.Set("x-api-key", l.apiKey)
-    req.Header.Set("anthropic-version", "2023-06-01")
-
-    resp, err := l.client.Do(req)
-    if err != nil {
-        return SkillScoreResult{}, fmt.Errorf("calling API: %w", err)
-    }
-    defer func() { _ = resp.Body.Close() }()
-
-    var claudeResp claudeResponse
-    if err := json.NewDecoder(resp.Body).Decode(&claudeResp); err != nil {
-        return SkillScoreResult{}, fmt.Errorf("decoding response: %w", err)
-    }
-    if claudeResp.Error != nil {
-        return SkillScoreResult{}, fmt.Errorf("API error: %s", claudeResp.Error.Message)
-    }
-    if len(claudeResp.Content) == 0 {
-        return SkillScoreResult{}, fmt.Errorf("empty response")
-    }
-
-    text := strings.TrimSpace(claudeResp.Content[0].Text)
-
-    text = strings.TrimPrefix(text, "```json")
-    text = strings.TrimPrefix(text, "```")
-    text = strings.TrimSuffix(text, "```")
-    text = strings.TrimSpace(text)
-
     var llmResp llmSkillResponse
-    if err := json.Unmarshal([]byte(text), &llmResp); err != nil {
-        return SkillScoreResult{}, fmt.Errorf("parsing response: %w (raw: %s)", err, text)
+    if err := l.complete(l.provider, SkillPromptVersion, prompt, 300, &llmResp); err != nil {
+        return SkillScoreResult{}, err
     }
 
     if llmResp.Score < 0 {
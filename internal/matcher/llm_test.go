@@ -13,7 +13,11 @@ func TestLLMMatcher(t *testing.T) {
 		t.Skip("ANTHROPIC_API_KEY not set, skipping LLM matcher test")
 	}
 
-	m := NewLLMMatcher(apiKey)
+	provider, err := NewProvider(ProviderConfig{Provider: ProviderAnthropic, APIKey: apiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewLLMMatcher(provider)
 
 	profile := database.Profile{
 		Name:               "Trung",
@@ -50,7 +54,11 @@ func TestHybridMatcher(t *testing.T) {
 		t.Skip("ANTHROPIC_API_KEY not set, skipping hybrid matcher test")
 	}
 
-	m := NewHybridMatcher(apiKey, 30.0)
+	provider, err := NewProvider(ProviderConfig{Provider: ProviderAnthropic, APIKey: apiKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewHybridMatcher(provider, 30.0)
 
 	profile := database.Profile{
 		Name:               "Trung",
@@ -11,4 +11,11 @@ type Matcher interface {
 	Match(job database.Job, profile database.Profile) MatchResult
 }
 
+// Tracker is implemented by matchers that call an LLM. Track caches their
+// replies and holds them to a token budget; Usage reports what they did.
+type Tracker interface {
+	Track(db *database.DB, runID string, budget Budget)
+	Usage() Usage
+}
+
 
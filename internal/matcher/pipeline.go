@@ -1,7 +1,10 @@
 package matcher
 
 import (
+	"fmt"
+
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 )
 
@@ -11,37 +14,107 @@ const (
 	ModeKeyword	ScoringMode = "keyword"
 	ModeLLM		ScoringMode = "llm"
 	ModeHybrid	ScoringMode = "hybrid"
+	// ModeSemantic scores by offline BM25 text similarity to the profile.
+	ModeSemantic	ScoringMode = "semantic"
 )
 
 type Pipeline struct {
 	keyword		*SkillScorer
 	llm			*LLMSkillScorer
+	semantic	*SemanticScorer
 	mode 		ScoringMode
 	threshold	float64
+	// firstStage is the scorer hybrid mode runs before deciding whether to
+	// call the LLM: keyword or semantic.
+	firstStage	ScoringMode
+}
+
+// ConfiguredMode returns the scoring mode set by matcher.type, keyword by
+// default.
+func ConfiguredMode() ScoringMode {
+	if mode := ScoringMode(viper.GetString("matcher.type")); mode != "" {
+		return mode
+	}
+	return ModeKeyword
 }
 
+// NewPipeline builds the configured pipeline, weighting keyword scores by
+// the configured scoring profile, or the default one if it is invalid.
 func NewPipeline() *Pipeline {
-	mode := ScoringMode(viper.GetString("matcher.type"))
-	if mode == "" {
-		mode = ModeKeyword
+	profile, err := LoadScoringProfile("")
+	if err != nil {
+		profile = DefaultScoringProfile
 	}
+	return NewPipelineWithProfile(profile)
+}
+
+// NewPipelineWithProfile builds the configured pipeline with keyword scores
+// weighted by the given scoring profile.
+func NewPipelineWithProfile(profile ScoringProfile) *Pipeline {
+	mode := ConfiguredMode()
 	threshold := viper.GetFloat64("matcher.llm_threshold")
 	if threshold == 0 {
 		threshold = 30
 	}
-	apiKey := viper.GetString("anthropic_api_key")
+
+	firstStage := ScoringMode(viper.GetString("matcher.first_stage"))
+	if firstStage != ModeSemantic {
+		firstStage = ModeKeyword
+	}
 
 	p := &Pipeline{
-		keyword:	NewSkillScorer(),
+		keyword:	NewSkillScorerWithProfile(profile),
 		mode: 		mode,
 		threshold:	threshold,
+		firstStage:	firstStage,
 	}
-	if (mode == ModeLLM || mode == ModeHybrid) && apiKey != "" {
-		p.llm = NewLLMSkillScorer(apiKey)
+	if mode == ModeLLM || mode == ModeHybrid {
+		// Without a usable provider the LLM stage is skipped.
+		if provider, err := LoadProvider(); err == nil {
+			p.llm = NewLLMSkillScorer(provider)
+		}
 	}
 	return p
 }
 
+// Index builds the semantic index over every stored job when the mode
+// needs one. Until it is called, semantic scoring falls back to keyword
+// scoring.
+func (p *Pipeline) Index(db *database.DB) error {
+	if p.mode != ModeSemantic && (p.mode != ModeHybrid || p.firstStage != ModeSemantic) {
+		return nil
+	}
+	jobs, err := db.QueryJobs(database.JobQuery{IncludeClosed: true})
+	if err != nil {
+		return fmt.Errorf("indexing jobs: %w", err)
+	}
+	p.semantic = NewSemanticScorer(NewSemanticIndex(jobs))
+	return nil
+}
+
+// Track caches LLM replies in db and holds LLM scoring to the configured
+// token budget for this run. Once the budget is spent, jobs are scored as
+// if no LLM were configured.
+func (p *Pipeline) Track(db *database.DB) {
+	p.TrackRun(db, uuid.New().String())
+}
+
+// TrackRun is Track under a given run ID, so that other LLM callers in the
+// same run share its per-run budget.
+func (p *Pipeline) TrackRun(db *database.DB, runID string) {
+	if p.llm != nil {
+		p.llm.Track(db, runID, LoadBudget())
+	}
+}
+
+// Usage reports the run's LLM usage; ok is false when no LLM is in use.
+func (p *Pipeline) Usage() (usage Usage, ok bool) {
+	if p.llm == nil {
+		return Usage{}, false
+	}
+	return p.llm.Usage(), true
+}
+
 func (p *Pipeline) Score(job database.Job, profile database.Profile) SkillScoreResult {
 	switch p.mode {
 	case ModeLLM:
@@ -51,16 +124,29 @@ func (p *Pipeline) Score(job database.Job, profile database.Profile) SkillScoreR
 			}
 		}
 		return p.keyword.Score(job, profile)
+	case ModeSemantic:
+		if p.semantic != nil {
+			return p.semantic.Score(job, profile)
+		}
+		return p.keyword.Score(job, profile)
 	case ModeHybrid:
-		keywordResult := p.keyword.Score(job, profile)
-		if p.llm != nil && keywordResult.Score >= p.threshold {
+		firstResult := p.first(job, profile)
+		if p.llm != nil && firstResult.Score >= p.threshold {
 			if result, err := p.llm.Score(job, profile); err == nil {
 				return result
 			}
 		}
-		return keywordResult
+		return firstResult
 		
 	default:
 		return p.keyword.Score(job, profile)
 	}
-}
\ No newline at end of file
+}
+
+// first runs hybrid mode's first stage.
+func (p *Pipeline) first(job database.Job, profile database.Profile) SkillScoreResult {
+	if p.firstStage == ModeSemantic && p.semantic != nil {
+		return p.semantic.Score(job, profile)
+	}
+	return p.keyword.Score(job, profile)
+}
@@ -0,0 +1,202 @@
+package matcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Provider sends a prompt to a language model and returns its reply.
+type Provider interface {
+	// Name identifies the provider, e.g. "anthropic".
+	Name() string
+	// Model is the model requests are sent to.
+	Model() string
+	Complete(ctx context.Context, req CompletionRequest) (Completion, error)
+}
+
+// CompletionRequest is a single-turn prompt.
+type CompletionRequest struct {
+	Prompt    string
+	MaxTokens int
+}
+
+// Completion is a model's reply and the tokens it cost, when the provider
+// reports them.
+type Completion struct {
+	Text         string
+	InputTokens  int
+	OutputTokens int
+}
+
+// Provider names accepted in llm.provider.
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+	ProviderOllama    = "ollama"
+)
+
+// Anthropic's default models per use. Skill scoring runs on every job and
+// takes the small model; search's overall match assessment keeps the larger
+// model it has always used.
+const (
+	anthropicScoringModel = "claude-haiku-4-5-20251001"
+	anthropicMatchModel   = "claude-sonnet-4-5-20250929"
+)
+
+// ProviderConfig configures a provider. Empty fields take the provider's
+// defaults.
+type ProviderConfig struct {
+	Provider string
+	BaseURL  string
+	Model    string
+	APIKey   string
+	// Timeout bounds a whole request, including reading the reply.
+	Timeout time.Duration
+	// ConnectTimeout bounds establishing the connection.
+	ConnectTimeout time.Duration
+}
+
+// LoadProviderConfig reads the llm section of config. The API key falls
+// back to anthropic_api_key or openai_api_key (or the ANTHROPIC_API_KEY and
+// OPENAI_API_KEY environment variables) for those providers.
+func LoadProviderConfig() ProviderConfig {
+	cfg := ProviderConfig{
+		Provider:       strings.ToLower(viper.GetString("llm.provider")),
+		BaseURL:        viper.GetString("llm.base_url"),
+		Model:          viper.GetString("llm.model"),
+		APIKey:         viper.GetString("llm.api_key"),
+		Timeout:        viper.GetDuration("llm.timeout"),
+		ConnectTimeout: viper.GetDuration("llm.connect_timeout"),
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderAnthropic
+	}
+	if cfg.APIKey == "" {
+		switch cfg.Provider {
+		case ProviderAnthropic:
+			cfg.APIKey = viper.GetString("anthropic_api_key")
+		case ProviderOpenAI:
+			cfg.APIKey = viper.GetString("openai_api_key")
+		}
+	}
+	return cfg
+}
+
+// LoadProvider builds the provider configured under llm, for skill scoring.
+func LoadProvider() (Provider, error) {
+	return NewProvider(LoadProviderConfig())
+}
+
+// LoadMatchProvider builds the provider for search's llm and hybrid
+// matchers. llm.match_model overrides llm.model for it; with neither set,
+// Anthropic uses its larger default model.
+func LoadMatchProvider() (Provider, error) {
+	return NewProvider(LoadMatchProviderConfig())
+}
+
+// LoadMatchProviderConfig is LoadProviderConfig with the match model
+// applied.
+func LoadMatchProviderConfig() ProviderConfig {
+	cfg := LoadProviderConfig()
+	if model := viper.GetString("llm.match_model"); model != "" {
+		cfg.Model = model
+	} else if cfg.Model == "" && cfg.Provider == ProviderAnthropic {
+		cfg.Model = anthropicMatchModel
+	}
+	return cfg
+}
+
+// NewProvider builds a provider from cfg.
+func NewProvider(cfg ProviderConfig) (Provider, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = 10 * time.Second
+	}
+	client := &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         (&net.Dialer{Timeout: cfg.ConnectTimeout}).DialContext,
+			TLSHandshakeTimeout: cfg.ConnectTimeout,
+		},
+	}
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+
+	switch cfg.Provider {
+	case "", ProviderAnthropic:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("no API key for anthropic: set llm.api_key or ANTHROPIC_API_KEY")
+		}
+		return &AnthropicProvider{baseURL: orDefault(baseURL, "https://api.anthropic.com"), model: orDefault(cfg.Model, anthropicScoringModel), apiKey: cfg.APIKey, client: client}, nil
+	case ProviderOpenAI:
+		// Self-hosted OpenAI-compatible servers often need no key.
+		return &OpenAIProvider{baseURL: orDefault(baseURL, "https://api.openai.com/v1"), model: orDefault(cfg.Model, "gpt-4o-mini"), apiKey: cfg.APIKey, client: client}, nil
+	case ProviderOllama:
+		return &OllamaProvider{baseURL: orDefault(baseURL, "http://localhost:11434"), model: orDefault(cfg.Model, "llama3.1"), client: client}, nil
+	}
+	return nil, fmt.Errorf("unknown LLM provider %q: use %s, %s or %s", cfg.Provider, ProviderAnthropic, ProviderOpenAI, ProviderOllama)
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// postJSON sends body to url and decodes the reply into out. Error replies
+// are returned with their status and the start of their body.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("API returned %s: %s", resp.Status, strings.TrimSpace(string(snippet)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// parseJSONReply decodes a model's JSON reply, tolerating a Markdown code
+// fence around it.
+func parseJSONReply(text string, out interface{}) error {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	text = strings.TrimSpace(text)
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("parsing response: %w (raw: %s)", err, text)
+	}
+	return nil
+}
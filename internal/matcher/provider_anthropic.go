@@ -0,0 +1,71 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AnthropicProvider calls the Anthropic Messages API.
+type AnthropicProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+type claudeRequest struct {
+	Model     string        `json:"model"`
+	MaxTokens int           `json:"max_tokens"`
+	Messages  []chatMessage `json:"messages"`
+}
+
+// chatMessage is one message of a conversation, in the shape all three
+// providers share.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type claudeResponse struct {
+	Content []claudeContent `json:"content"`
+	Usage   struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *claudeError `json:"error,omitempty"`
+}
+
+type claudeContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type claudeError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (p *AnthropicProvider) Name() string  { return ProviderAnthropic }
+func (p *AnthropicProvider) Model() string { return p.model }
+
+func (p *AnthropicProvider) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	body := claudeRequest{
+		Model:     p.model,
+		MaxTokens: req.MaxTokens,
+		Messages:  []chatMessage{{Role: "user", Content: req.Prompt}},
+	}
+	headers := map[string]string{"x-api-key": p.apiKey, "anthropic-version": "2023-06-01"}
+
+	var resp claudeResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/v1/messages", headers, body, &resp); err != nil {
+		return Completion{}, err
+	}
+	if resp.Error != nil {
+		return Completion{}, fmt.Errorf("API error: %s", resp.Error.Message)
+	}
+	if len(resp.Content) == 0 {
+		return Completion{}, fmt.Errorf("empty response from API")
+	}
+	return Completion{Text: resp.Content[0].Text, InputTokens: resp.Usage.InputTokens, OutputTokens: resp.Usage.OutputTokens}, nil
+}
@@ -0,0 +1,55 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// OllamaProvider calls a local or remote Ollama server's chat API.
+type OllamaProvider struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  struct {
+		NumPredict int `json:"num_predict,omitempty"`
+	} `json:"options"`
+}
+
+type ollamaResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error,omitempty"`
+}
+
+func (p *OllamaProvider) Name() string  { return ProviderOllama }
+func (p *OllamaProvider) Model() string { return p.model }
+
+func (p *OllamaProvider) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	body := ollamaRequest{
+		Model:    p.model,
+		Messages: []chatMessage{{Role: "user", Content: req.Prompt}},
+	}
+	body.Options.NumPredict = req.MaxTokens
+
+	var resp ollamaResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/api/chat", nil, body, &resp); err != nil {
+		return Completion{}, err
+	}
+	if resp.Error != "" {
+		return Completion{}, fmt.Errorf("API error: %s", resp.Error)
+	}
+	if resp.Message.Content == "" {
+		return Completion{}, fmt.Errorf("empty response from API")
+	}
+	return Completion{Text: resp.Message.Content, InputTokens: resp.PromptEvalCount, OutputTokens: resp.EvalCount}, nil
+}
@@ -0,0 +1,64 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// OpenAIProvider calls an OpenAI-compatible chat completions endpoint:
+// OpenAI itself, or a self-hosted server such as vLLM or llama.cpp.
+type OpenAIProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+type openAIRequest struct {
+	Model     string        `json:"model"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+	Messages  []chatMessage `json:"messages"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (p *OpenAIProvider) Name() string  { return ProviderOpenAI }
+func (p *OpenAIProvider) Model() string { return p.model }
+
+func (p *OpenAIProvider) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	body := openAIRequest{
+		Model:     p.model,
+		MaxTokens: req.MaxTokens,
+		Messages:  []chatMessage{{Role: "user", Content: req.Prompt}},
+	}
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+
+	var resp openAIResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/chat/completions", headers, body, &resp); err != nil {
+		return Completion{}, err
+	}
+	if resp.Error != nil {
+		return Completion{}, fmt.Errorf("API error: %s", resp.Error.Message)
+	}
+	if len(resp.Choices) == 0 {
+		return Completion{}, fmt.Errorf("empty response from API")
+	}
+	return Completion{Text: resp.Choices[0].Message.Content, InputTokens: resp.Usage.PromptTokens, OutputTokens: resp.Usage.CompletionTokens}, nil
+}
@@ -0,0 +1,156 @@
+package matcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/spf13/viper"
+)
+
+// fakeServer answers one endpoint with reply and records the request body.
+func fakeServer(t *testing.T, path string, check func(r *http.Request), reply string) (*httptest.Server, *map[string]interface{}) {
+	t.Helper()
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		if check != nil {
+			check(r)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &body
+}
+
+func TestProviders(t *testing.T) {
+	tests := []struct {
+		provider string
+		path     string
+		header   string
+		want     string
+		reply    string
+	}{
+		{ProviderAnthropic, "/v1/messages", "X-Api-Key", "secret",
+			`{"content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":12,"output_tokens":3}}`},
+		{ProviderOpenAI, "/chat/completions", "Authorization", "Bearer secret",
+			`{"choices":[{"message":{"role":"assistant","content":"hi"}}],"usage":{"prompt_tokens":12,"completion_tokens":3}}`},
+		{ProviderOllama, "/api/chat", "", "",
+			`{"message":{"role":"assistant","content":"hi"},"done":true,"prompt_eval_count":12,"eval_count":3}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			srv, body := fakeServer(t, tt.path, func(r *http.Request) {
+				if tt.header != "" && r.Header.Get(tt.header) != tt.want {
+					t.Errorf("%s = %q, want %q", tt.header, r.Header.Get(tt.header), tt.want)
+				}
+			}, tt.reply)
+
+			p, err := NewProvider(ProviderConfig{Provider: tt.provider, BaseURL: srv.URL + "/", Model: "m1", APIKey: "secret"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := p.Complete(context.Background(), CompletionRequest{Prompt: "hello", MaxTokens: 50})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Text != "hi" || c.InputTokens != 12 || c.OutputTokens != 3 {
+				t.Errorf("completion = %+v", c)
+			}
+			if (*body)["model"] != "m1" {
+				t.Errorf("model = %v, want m1", (*body)["model"])
+			}
+		})
+	}
+}
+
+func TestProviderErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow/api/chat" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		http.Error(w, `{"error":"model not found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	p, _ := NewProvider(ProviderConfig{Provider: ProviderOllama, BaseURL: srv.URL})
+	if _, err := p.Complete(context.Background(), CompletionRequest{Prompt: "x"}); err == nil {
+		t.Error("404 reply accepted")
+	}
+
+	p, _ = NewProvider(ProviderConfig{Provider: ProviderOllama, BaseURL: srv.URL + "/slow", Timeout: 50 * time.Millisecond})
+	if _, err := p.Complete(context.Background(), CompletionRequest{Prompt: "x"}); err == nil {
+		t.Error("request outlived its timeout")
+	}
+
+	if _, err := NewProvider(ProviderConfig{Provider: ProviderAnthropic}); err == nil {
+		t.Error("anthropic provider built without an API key")
+	}
+	if _, err := NewProvider(ProviderConfig{Provider: "bard"}); err == nil {
+		t.Error("unknown provider accepted")
+	}
+}
+
+func TestLoadProviderConfig(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("anthropic_api_key", "from-legacy-key")
+	if cfg := LoadProviderConfig(); cfg.Provider != ProviderAnthropic || cfg.APIKey != "from-legacy-key" {
+		t.Errorf("default config = %+v", cfg)
+	}
+
+	viper.Set("llm.provider", "OpenAI")
+	viper.Set("llm.base_url", "http://gpu-box:8000/v1")
+	viper.Set("llm.timeout", "2m")
+	cfg := LoadProviderConfig()
+	if cfg.Provider != ProviderOpenAI || cfg.APIKey != "" || cfg.BaseURL != "http://gpu-box:8000/v1" || cfg.Timeout != 2*time.Minute {
+		t.Errorf("openai config = %+v", cfg)
+	}
+}
+
+func TestLoadMatchProviderConfig(t *testing.T) {
+	defer viper.Reset()
+	if cfg := LoadMatchProviderConfig(); cfg.Model != anthropicMatchModel {
+		t.Errorf("anthropic match model = %q, want %q", cfg.Model, anthropicMatchModel)
+	}
+	if cfg := LoadProviderConfig(); cfg.Model != "" {
+		t.Errorf("scoring model = %q, want the provider default", cfg.Model)
+	}
+
+	viper.Set("llm.model", "claude-opus-4-1")
+	if cfg := LoadMatchProviderConfig(); cfg.Model != "claude-opus-4-1" {
+		t.Errorf("match model = %q, want llm.model", cfg.Model)
+	}
+	viper.Set("llm.match_model", "claude-sonnet-4-5")
+	if cfg := LoadMatchProviderConfig(); cfg.Model != "claude-sonnet-4-5" {
+		t.Errorf("match model = %q, want llm.match_model", cfg.Model)
+	}
+
+	viper.Reset()
+	viper.Set("llm.provider", "ollama")
+	if cfg := LoadMatchProviderConfig(); cfg.Model != "" {
+		t.Errorf("ollama match model = %q, want the provider default", cfg.Model)
+	}
+}
+
+func TestLLMSkillScorerParsesFencedReply(t *testing.T) {
+	srv, _ := fakeServer(t, "/api/chat", nil,
+		"{\"message\":{\"content\":\"```json\\n{\\\"score\\\": 120, \\\"matched_skills\\\": [\\\"Go\\\"], \\\"reason\\\": \\\"fits\\\"}\\n```\"}}")
+	p, _ := NewProvider(ProviderConfig{Provider: ProviderOllama, BaseURL: srv.URL})
+
+	job := database.Job{Description: strPtr("Requirements: Go")}
+	got, err := NewLLMSkillScorer(p).Score(job, database.Profile{Skills: `["Go"]`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Score != 100 || len(got.MatchedSkills) != 1 || got.Reason != "fits" {
+		t.Errorf("result = %+v, want score clamped to 100", got)
+	}
+}
@@ -0,0 +1,135 @@
+package matcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EmptySection says what a skill section the posting does not list is worth.
+type EmptySection string
+
+const (
+	// EmptyFull awards the section's whole weight, as if every skill in it
+	// matched. This is the original behaviour.
+	EmptyFull EmptySection = "full"
+	// EmptyZero awards nothing for the section.
+	EmptyZero EmptySection = "zero"
+	// EmptyRedistribute shares the section's weight among the sections the
+	// posting does list.
+	EmptyRedistribute EmptySection = "redistribute"
+)
+
+// ScoringProfile holds the weights of the required, preferred and mentioned
+// skill sections and how empty sections are treated. Weights are relative:
+// scores are always scaled to 0–100.
+type ScoringProfile struct {
+	Name         string       `json:"name"`
+	Required     float64      `json:"required"`
+	Preferred    float64      `json:"preferred"`
+	Mentioned    float64      `json:"mentioned"`
+	EmptySection EmptySection `json:"empty_section"`
+}
+
+// DefaultScoringProfile is the 70/20/10 split jobgo has always used.
+var DefaultScoringProfile = ScoringProfile{Name: "default", Required: 70, Preferred: 20, Mentioned: 10, EmptySection: EmptyFull}
+
+// builtinProfiles are available without any configuration. Profiles in
+// config with the same name replace them.
+var builtinProfiles = map[string]ScoringProfile{
+	"strict":       {Name: "strict", Required: 70, Preferred: 20, Mentioned: 10, EmptySection: EmptyZero},
+	"proportional": {Name: "proportional", Required: 70, Preferred: 20, Mentioned: 10, EmptySection: EmptyRedistribute},
+}
+
+// String describes the profile, e.g.
+// "default (required 70, preferred 20, mentioned 10; empty sections: full)".
+func (p ScoringProfile) String() string {
+	return fmt.Sprintf("%s (required %g, preferred %g, mentioned %g; empty sections: %s)",
+		p.Name, p.Required, p.Preferred, p.Mentioned, p.EmptySection)
+}
+
+// Validate reports a profile that cannot produce a score.
+func (p ScoringProfile) Validate() error {
+	if p.Required < 0 || p.Preferred < 0 || p.Mentioned < 0 {
+		return fmt.Errorf("scoring profile %q: weights must not be negative", p.Name)
+	}
+	if p.Required+p.Preferred+p.Mentioned <= 0 {
+		return fmt.Errorf("scoring profile %q: at least one weight must be positive", p.Name)
+	}
+	switch p.EmptySection {
+	case EmptyFull, EmptyZero, EmptyRedistribute:
+	default:
+		return fmt.Errorf("scoring profile %q: empty_section must be full, zero or redistribute, not %q", p.Name, p.EmptySection)
+	}
+	return nil
+}
+
+// ScoringProfiles lists the names of the built-in and configured profiles.
+func ScoringProfiles() []string {
+	names := map[string]bool{DefaultScoringProfile.Name: true}
+	for name := range builtinProfiles {
+		names[name] = true
+	}
+	for name := range viper.GetStringMap("scoring.profiles") {
+		names[strings.ToLower(name)] = true
+	}
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// LoadScoringProfile returns the named profile. An empty name selects
+// scoring.profile from config, or "default".
+//
+// The default profile's weights come from scoring.required,
+// scoring.preferred, scoring.mentioned and scoring.empty_section; profiles
+// under scoring.profiles.<name> set the same keys, and any they leave out
+// are taken from the default profile.
+func LoadScoringProfile(name string) (ScoringProfile, error) {
+	if name == "" {
+		name = viper.GetString("scoring.profile")
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultScoringProfile.Name
+	}
+
+	base := overlayProfile(DefaultScoringProfile, "scoring")
+	p := base
+	if name != DefaultScoringProfile.Name {
+		key := "scoring.profiles." + name
+		builtin, ok := builtinProfiles[name]
+		switch {
+		case viper.IsSet(key):
+			p = overlayProfile(base, key)
+		case ok:
+			p = builtin
+		default:
+			return ScoringProfile{}, fmt.Errorf("unknown scoring profile %q (have: %s)", name, strings.Join(ScoringProfiles(), ", "))
+		}
+	}
+	p.Name = name
+	return p, p.Validate()
+}
+
+// overlayProfile returns p with any weights set under the config prefix.
+func overlayProfile(p ScoringProfile, prefix string) ScoringProfile {
+	if viper.IsSet(prefix + ".required") {
+		p.Required = viper.GetFloat64(prefix + ".required")
+	}
+	if viper.IsSet(prefix + ".preferred") {
+		p.Preferred = viper.GetFloat64(prefix + ".preferred")
+	}
+	if viper.IsSet(prefix + ".mentioned") {
+		p.Mentioned = viper.GetFloat64(prefix + ".mentioned")
+	}
+	if viper.IsSet(prefix + ".empty_section") {
+		p.EmptySection = EmptySection(strings.ToLower(viper.GetString(prefix + ".empty_section")))
+	}
+	return p
+}
@@ -0,0 +1,248 @@
+package matcher
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/skills"
+)
+
+// BM25 parameters: k1 sets how quickly repeated terms stop adding weight,
+// b how strongly long descriptions are discounted.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// semanticKnee shapes how semantic scores saturate: a job reaching this
+// share of the profile's self-similarity scores 60, and one reaching all of
+// it 100.
+const semanticKnee = 0.2
+
+var termRE = regexp.MustCompile(`[a-z0-9][a-z0-9+#]*(?:\.[a-z0-9]+)*`)
+
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`a about above after all also am an and any are as at be because been
+		before being below between both but by can could did do does doing down during each etc few for
+		from further had has have having he her here hers him his how i if in into is it its itself just
+		me more most my no nor not now of off on once only or other our ours out over own same she should
+		so some such than that the their theirs them then there these they this those through to too under
+		until up very was we were what when where which while who whom why will with would you your yours
+		able across ability etc experience work working team teams role job join including within well new
+		strong years year plus using use used`) {
+		stopwords[w] = true
+	}
+}
+
+// Tokenize splits text into the terms the semantic index works with:
+// lowercased words, without stopwords, plurals folded, and skill aliases
+// resolved to their canonical names ("k8s" and "kubernetes" are the same
+// term).
+func Tokenize(text string) []string {
+	words := termRE.FindAllString(strings.ToLower(text), -1)
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		if stopwords[w] || len(w) < 2 && w != "c" && w != "r" {
+			continue
+		}
+		if skills.IsKnown(w) {
+			w = strings.ToLower(skills.Normalize(w))
+		} else if len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+type document struct {
+	terms  map[string]int
+	length int
+}
+
+func newDocument(text string) document {
+	d := document{terms: map[string]int{}}
+	for _, t := range Tokenize(text) {
+		d.terms[t]++
+		d.length++
+	}
+	return d
+}
+
+// SemanticIndex is a BM25 index over job descriptions. It needs no network
+// or model: term weights come from how rare each term is across the
+// indexed jobs.
+type SemanticIndex struct {
+	docs   map[string]document
+	df     map[string]int
+	avgLen float64
+	// self caches, per query, the raw score of the query against itself,
+	// which scores are scaled against.
+	self map[string]float64
+}
+
+// NewSemanticIndex indexes the given jobs by title and description.
+func NewSemanticIndex(jobs []database.Job) *SemanticIndex {
+	idx := &SemanticIndex{docs: make(map[string]document, len(jobs)), df: map[string]int{}, self: map[string]float64{}}
+	total := 0
+	for _, j := range jobs {
+		d := newDocument(jobText(j))
+		idx.docs[j.ID] = d
+		total += d.length
+		for t := range d.terms {
+			idx.df[t]++
+		}
+	}
+	if len(idx.docs) > 0 {
+		idx.avgLen = float64(total) / float64(len(idx.docs))
+	}
+	return idx
+}
+
+// Len returns the number of indexed jobs.
+func (idx *SemanticIndex) Len() int { return len(idx.docs) }
+
+func jobText(j database.Job) string {
+	if j.Description == nil {
+		return j.Title
+	}
+	return j.Title + "\n" + *j.Description
+}
+
+func (idx *SemanticIndex) idf(term string) float64 {
+	n, df := float64(len(idx.docs)), float64(idx.df[term])
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// termScore is one query term's BM25 contribution to a document.
+func (idx *SemanticIndex) termScore(d document, term string) float64 {
+	tf := float64(d.terms[term])
+	if tf == 0 {
+		return 0
+	}
+	avg := idx.avgLen
+	if avg == 0 {
+		avg = float64(d.length)
+	}
+	norm := 1 - bm25B + bm25B*float64(d.length)/avg
+	return idx.idf(term) * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+}
+
+func (idx *SemanticIndex) score(d document, query []string) float64 {
+	total := 0.0
+	for _, t := range query {
+		total += idx.termScore(d, t)
+	}
+	return total
+}
+
+// selfScore is the score of a document holding each query term once: what
+// a job that says everything the profile says would get. Unlike the best
+// indexed job, it doesn't move as jobs are scraped.
+func (idx *SemanticIndex) selfScore(query []string) float64 {
+	key := strings.Join(query, " ")
+	if v, ok := idx.self[key]; ok {
+		return v
+	}
+	d := document{terms: make(map[string]int, len(query)), length: len(query)}
+	for _, t := range query {
+		d.terms[t] = 1
+	}
+	self := idx.score(d, query)
+	idx.self[key] = self
+	return self
+}
+
+// SemanticScorer scores jobs by BM25 similarity between the job and the
+// profile's resume text, skills and preferred roles.
+type SemanticScorer struct {
+	index *SemanticIndex
+}
+
+func NewSemanticScorer(index *SemanticIndex) *SemanticScorer {
+	return &SemanticScorer{index: index}
+}
+
+// profileQuery is the profile as a set of distinct query terms.
+func profileQuery(profile database.Profile) []string {
+	text := profile.ResumeRaw + "\n" + strings.Join(parseJSONArray(profile.Skills), " ") + "\n" + strings.Join(parseJSONArray(profile.PreferredRoles), " ")
+	seen := map[string]bool{}
+	var query []string
+	for _, t := range Tokenize(text) {
+		if !seen[t] {
+			seen[t] = true
+			query = append(query, t)
+		}
+	}
+	sort.Strings(query)
+	return query
+}
+
+// Score rates the job 0–100 against the profile by how much of the
+// profile's self-similarity the job reaches, saturating so that partial
+// overlap with a long resume still scores well. Scores don't depend on which
+// other jobs have been scraped beyond their term rarity.
+func (s *SemanticScorer) Score(job database.Job, profile database.Profile) SkillScoreResult {
+	query := profileQuery(profile)
+	if len(query) == 0 {
+		return SkillScoreResult{Score: 0, Reason: "No resume or skills in profile"}
+	}
+	if job.Description == nil || *job.Description == "" {
+		return SkillScoreResult{Score: 0, Reason: "No job description"}
+	}
+
+	d, ok := s.index.docs[job.ID]
+	if !ok {
+		d = newDocument(jobText(job))
+	}
+	raw := s.index.score(d, query)
+	score := 0.0
+	if self := s.index.selfScore(query); self > 0 {
+		share := math.Min(raw/self, 1)
+		score = 100 * share * (1 + semanticKnee) / (share + semanticKnee)
+	}
+
+	// The terms that contributed most explain the score.
+	type contribution struct {
+		term  string
+		score float64
+	}
+	var top []contribution
+	for _, t := range query {
+		if v := s.index.termScore(d, t); v > 0 {
+			top = append(top, contribution{t, v})
+		}
+	}
+	sort.Slice(top, func(a, b int) bool { return top[a].score > top[b].score })
+	if len(top) > 10 {
+		top = top[:10]
+	}
+	matched := make([]string, 0, len(top))
+	for _, c := range top {
+		matched = append(matched, c.term)
+	}
+
+	userSet := make(map[string]bool)
+	for _, sk := range parseJSONArray(profile.Skills) {
+		userSet[skills.Normalize(sk)] = true
+	}
+	jobSkills := skills.ExtractFromJob(*job.Description)
+	missing := difference(userSet, append(jobSkills.Required, jobSkills.Preferred...))
+
+	reason := "No terms in common with your profile"
+	if len(matched) > 0 {
+		reason = fmt.Sprintf("Text similarity %.0f/100. Top shared terms: %s.", score, strings.Join(matched, ", "))
+	}
+	return SkillScoreResult{
+		Score:         score,
+		MatchedSkills: matched,
+		MissingSkills: missing,
+		Reason:        reason,
+	}
+}
@@ -0,0 +1,87 @@
+package matcher
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/spf13/viper"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Experience with K8s and Golang services; C++ and node.js a plus.")
+	for _, want := range []string{"kubernetes", "go", "service", "c++"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("Tokenize = %v, missing %q", got, want)
+		}
+	}
+	for _, stop := range []string{"with", "and", "a", "experience"} {
+		if slices.Contains(got, stop) {
+			t.Errorf("Tokenize = %v, kept stopword %q", got, stop)
+		}
+	}
+}
+
+func semanticJobs() []database.Job {
+	return []database.Job{
+		{ID: "ml", Title: "Machine Learning Engineer", Description: strPtr("Train recommendation models with PyTorch. Feature pipelines on Spark, model serving, ranking and retrieval.")},
+		{ID: "fe", Title: "Frontend Engineer", Description: strPtr("Build React interfaces with TypeScript and CSS. Accessibility, design systems.")},
+		{ID: "data", Title: "Data Engineer", Description: strPtr("Spark and Airflow pipelines, warehouse modelling in SQL.")},
+	}
+}
+
+func TestSemanticScorer(t *testing.T) {
+	jobs := semanticJobs()
+	scorer := NewSemanticScorer(NewSemanticIndex(jobs))
+	// Nothing here is in the skill taxonomy's required list, so keyword
+	// matching sees little; the text still says who fits.
+	profile := database.Profile{ResumeRaw: "Built recommendation and retrieval models; ranking research; model serving at scale."}
+
+	scores := map[string]float64{}
+	for _, j := range jobs {
+		scores[j.ID] = scorer.Score(j, profile).Score
+	}
+	if scores["ml"] < 60 || scores["ml"] <= scores["data"] {
+		t.Errorf("best match scored %.1f (data %.1f), want at least 60 and the highest", scores["ml"], scores["data"])
+	}
+	if scores["fe"] != 0 {
+		t.Errorf("unrelated job scored %.1f, want 0", scores["fe"])
+	}
+	if r := scorer.Score(jobs[0], profile); !slices.Contains(r.MatchedSkills, "recommendation") {
+		t.Errorf("top terms = %v, want recommendation among them", r.MatchedSkills)
+	}
+	if r := scorer.Score(jobs[0], database.Profile{}); r.Score != 0 {
+		t.Errorf("empty profile scored %.1f", r.Score)
+	}
+}
+
+func TestSemanticScoreIgnoresBetterJobs(t *testing.T) {
+	profile := database.Profile{ResumeRaw: "Built recommendation and retrieval models; ranking research; model serving at scale."}
+	jobs := semanticJobs()
+	before := NewSemanticScorer(NewSemanticIndex(jobs)).Score(jobs[0], profile).Score
+
+	// A job that matches the profile better must not pull the others down.
+	jobs = append(jobs, database.Job{ID: "copy", Title: "Research Engineer", Description: strPtr(profile.ResumeRaw)})
+	after := NewSemanticScorer(NewSemanticIndex(jobs)).Score(jobs[0], profile).Score
+	if after < before-5 {
+		t.Errorf("score fell from %.1f to %.1f when a better match was indexed", before, after)
+	}
+}
+
+func TestPipelineSemanticMode(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("matcher.type", "semantic")
+	profile := database.Profile{Skills: `["Spark"]`, ResumeRaw: "Spark and Airflow pipelines"}
+
+	p := NewPipeline()
+	job := semanticJobs()[2]
+	// Not indexed yet: keyword scoring stands in.
+	if got, want := p.Score(job, profile), NewSkillScorer().Score(job, profile); got.Score != want.Score {
+		t.Errorf("unindexed score = %.1f, want keyword %.1f", got.Score, want.Score)
+	}
+
+	p.semantic = NewSemanticScorer(NewSemanticIndex(semanticJobs()))
+	if got := p.Score(job, profile); got.Score < 80 {
+		t.Errorf("semantic score = %.1f (%s), want at least 80", got.Score, got.Reason)
+	}
+}
@@ -15,19 +15,53 @@ type SkillScoreResult struct {
     Reason        string   `json:"reason"`
 }
 
-type SkillScorer struct{}
+type SkillScorer struct {
+    profile ScoringProfile
+}
 
 func NewSkillScorer() *SkillScorer {
-    return &SkillScorer{}
+    return &SkillScorer{profile: DefaultScoringProfile}
+}
+
+// NewSkillScorerWithProfile weights sections by the given scoring profile.
+func NewSkillScorerWithProfile(profile ScoringProfile) *SkillScorer {
+    return &SkillScorer{profile: profile}
+}
+
+// SectionScore is one skill section's contribution to a score.
+type SectionScore struct {
+    Section string   `json:"section"`
+    Weight  float64  `json:"weight"`
+    Total   int      `json:"total"`
+    Matched []string `json:"matched"`
+    Missing []string `json:"missing"`
+    Points  float64  `json:"points"`
+    Note    string   `json:"note,omitempty"`
+}
+
+// Explanation breaks a keyword score down by section. Points across
+// sections add up to the result's score.
+type Explanation struct {
+    Profile  ScoringProfile   `json:"profile"`
+    Sections []SectionScore   `json:"sections"`
+    Result   SkillScoreResult `json:"result"`
 }
 
 func (s *SkillScorer) Score(job database.Job, profile database.Profile) SkillScoreResult {
+    return s.Explain(job, profile).Result
+}
+
+// Explain scores the job and reports what each section contributed.
+func (s *SkillScorer) Explain(job database.Job, profile database.Profile) Explanation {
+    e := Explanation{Profile: s.profile}
     userSkills := parseJSONArray(profile.Skills)
     if len(userSkills) == 0 {
-        return SkillScoreResult{Score: 0, Reason: "No skills in profile"}
+        e.Result = SkillScoreResult{Score: 0, Reason: "No skills in profile"}
+        return e
     }
     if job.Description == nil || *job.Description == "" {
-        return SkillScoreResult{Score: 0, Reason: "No job description"}
+        e.Result = SkillScoreResult{Score: 0, Reason: "No job description"}
+        return e
     }
 
     jobSkills := skills.ExtractFromJob(*job.Description)
@@ -37,39 +71,71 @@ func (s *SkillScorer) Score(job database.Job, profile database.Profile) SkillSco
         userSet[skills.Normalize(s)] = true
     }
 
-    requiredMatched  := intersect(userSet, jobSkills.Required)
-    preferredMatched := intersect(userSet, jobSkills.Preferred)
-    mentionedMatched := intersect(userSet, jobSkills.Mentioned)
-
+    e.Sections = s.sections(userSet, jobSkills)
     var score float64
-    if len(jobSkills.Required) > 0 {
-        score += float64(len(requiredMatched)) / float64(len(jobSkills.Required)) * 70
-    } else {
-        score += 70
+    var matched []string
+    for _, sec := range e.Sections {
+        score += sec.Points
+        matched = append(matched, sec.Matched...)
     }
-    if len(jobSkills.Preferred) > 0 {
-        score += float64(len(preferredMatched)) / float64(len(jobSkills.Preferred)) * 20
-    } else {
-        score += 20
-    }
-    if len(jobSkills.Mentioned) > 0 {
-        score += float64(len(mentionedMatched)) / float64(len(jobSkills.Mentioned)) * 10
-    } else {
-        score += 10
-    }
-
-    matched := append(append(requiredMatched, preferredMatched...), mentionedMatched...)
 
     missing := difference(userSet, append(jobSkills.Required, jobSkills.Preferred...))
 
-    reason := buildReason(requiredMatched, jobSkills.Required, missing)
+    reason := buildReason(e.Sections[0].Matched, jobSkills.Required, missing)
 
-    return SkillScoreResult{
+    e.Result = SkillScoreResult{
         Score:         score,
         MatchedSkills: matched,
         MissingSkills: missing,
         Reason:        reason,
     }
+    return e
+}
+
+// sections scores the required, preferred and mentioned sections, in that
+// order, scaled so a perfect match across all of them is 100.
+func (s *SkillScorer) sections(userSet map[string]bool, jobSkills skills.JobSkills) []SectionScore {
+    p := s.profile
+    sections := []SectionScore{
+        {Section: "required", Weight: p.Required, Total: len(jobSkills.Required), Matched: intersect(userSet, jobSkills.Required), Missing: difference(userSet, jobSkills.Required)},
+        {Section: "preferred", Weight: p.Preferred, Total: len(jobSkills.Preferred), Matched: intersect(userSet, jobSkills.Preferred), Missing: difference(userSet, jobSkills.Preferred)},
+        {Section: "mentioned", Weight: p.Mentioned, Total: len(jobSkills.Mentioned), Matched: intersect(userSet, jobSkills.Mentioned), Missing: difference(userSet, jobSkills.Mentioned)},
+    }
+
+    var total, listed float64
+    for _, sec := range sections {
+        total += sec.Weight
+        if sec.Total > 0 {
+            listed += sec.Weight
+        }
+    }
+    if total <= 0 {
+        return sections
+    }
+
+    // Redistributing the weight of empty sections scales the listed ones up.
+    scale := 100 / total
+    if p.EmptySection == EmptyRedistribute && listed > 0 {
+        scale = 100 / listed
+    }
+
+    for i := range sections {
+        sec := &sections[i]
+        if sec.Total > 0 {
+            sec.Points = float64(len(sec.Matched)) / float64(sec.Total) * sec.Weight * scale
+            continue
+        }
+        switch p.EmptySection {
+        case EmptyFull:
+            sec.Points = sec.Weight * scale
+            sec.Note = "none listed: full points"
+        case EmptyRedistribute:
+            sec.Note = "none listed: weight shared with other sections"
+        default:
+            sec.Note = "none listed: no points"
+        }
+    }
+    return sections
 }
 
 func intersect(userSet map[string]bool, jobSkills []string) []string {
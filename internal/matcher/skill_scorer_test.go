@@ -4,6 +4,7 @@ import (
     "testing"
 
     "github.com/Trungsherlock/jobgo/internal/database"
+    "github.com/spf13/viper"
 )
 
 func TestSkillScorer(t *testing.T) {
@@ -67,3 +68,76 @@ func TestSkillScorer_NoProfile(t *testing.T) {
         t.Errorf("expected score 0 for empty profile, got %.1f", result.Score)
     }
 }
+
+func TestSkillScorer_EmptySections(t *testing.T) {
+    profile := database.Profile{Skills: `["Go","Docker"]`}
+    // Only a required section, half matched.
+    job := database.Job{Description: strPtr("Requirements:\nGo, Java")}
+
+    tests := []struct {
+        empty EmptySection
+        want  float64
+    }{
+        {EmptyFull, 35 + 20 + 10},
+        {EmptyZero, 35},
+        {EmptyRedistribute, 50},
+    }
+    for _, tt := range tests {
+        t.Run(string(tt.empty), func(t *testing.T) {
+            p := DefaultScoringProfile
+            p.EmptySection = tt.empty
+            e := NewSkillScorerWithProfile(p).Explain(job, profile)
+            if e.Result.Score != tt.want {
+                t.Errorf("score = %.1f, want %.1f; sections = %+v", e.Result.Score, tt.want, e.Sections)
+            }
+            sum := 0.0
+            for _, s := range e.Sections {
+                sum += s.Points
+            }
+            if sum != e.Result.Score {
+                t.Errorf("section points add up to %.1f, score is %.1f", sum, e.Result.Score)
+            }
+        })
+    }
+}
+
+func TestSkillScorer_WeightsScaleTo100(t *testing.T) {
+    profile := database.Profile{Skills: `["Go"]`}
+    job := database.Job{Description: strPtr("Requirements:\nGo")}
+    p := ScoringProfile{Name: "custom", Required: 3, Preferred: 1, Mentioned: 0, EmptySection: EmptyZero}
+    if got := NewSkillScorerWithProfile(p).Score(job, profile).Score; got != 75 {
+        t.Errorf("score = %.1f, want 75", got)
+    }
+}
+
+func TestLoadScoringProfile(t *testing.T) {
+    defer viper.Reset()
+    viper.Set("scoring.required", 60)
+    viper.Set("scoring.profiles.backend.preferred", 30)
+    viper.Set("scoring.profiles.backend.empty_section", "zero")
+    viper.Set("scoring.profiles.broken.empty_section", "sometimes")
+
+    p, err := LoadScoringProfile("")
+    if err != nil || p.Name != "default" || p.Required != 60 || p.EmptySection != EmptyFull {
+        t.Errorf("default = %+v, %v", p, err)
+    }
+    // Unset keys come from the default profile.
+    p, err = LoadScoringProfile("backend")
+    if err != nil || p.Required != 60 || p.Preferred != 30 || p.Mentioned != 10 || p.EmptySection != EmptyZero {
+        t.Errorf("backend = %+v, %v", p, err)
+    }
+    if p, err = LoadScoringProfile("strict"); err != nil || p.EmptySection != EmptyZero {
+        t.Errorf("strict = %+v, %v", p, err)
+    }
+    if _, err := LoadScoringProfile("broken"); err == nil {
+        t.Error("invalid empty_section accepted")
+    }
+    if _, err := LoadScoringProfile("missing"); err == nil {
+        t.Error("unknown profile accepted")
+    }
+
+    viper.Set("scoring.profile", "backend")
+    if p, _ := LoadScoringProfile(""); p.Name != "backend" {
+        t.Errorf("configured profile = %q, want backend", p.Name)
+    }
+}
@@ -0,0 +1,314 @@
+// Package ranking blends a job's skill score with how well its seniority,
+// location, sponsorship outlook and age suit the user into one final score,
+// which is the default order jobs are listed in.
+package ranking
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/freshness"
+	"github.com/Trungsherlock/jobgo/internal/geo"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
+	"github.com/spf13/viper"
+)
+
+// Component names, as stored in a job's score breakdown.
+const (
+	ComponentSkill     = "skill"
+	ComponentSeniority = "seniority"
+	ComponentLocation  = "location"
+	ComponentFreshness = "freshness"
+	ComponentH1B       = "h1b"
+)
+
+// Weights are the relative weights of the blended components. A component
+// that doesn't apply to a job, such as location when the profile has no
+// preferred locations, gives its weight to the others.
+type Weights struct {
+	Skill     float64 `json:"skill"`
+	Seniority float64 `json:"seniority"`
+	Location  float64 `json:"location"`
+	Freshness float64 `json:"freshness"`
+}
+
+// DefaultWeights keep the skill score the main signal.
+var DefaultWeights = Weights{Skill: 60, Seniority: 15, Location: 15, Freshness: 10}
+
+// LoadWeights returns DefaultWeights overridden by ranking.weights.* in
+// config. Negative weights count as zero.
+func LoadWeights() Weights {
+	w := DefaultWeights
+	for key, v := range map[string]*float64{"skill": &w.Skill, "seniority": &w.Seniority, "location": &w.Location, "freshness": &w.Freshness} {
+		if viper.IsSet("ranking.weights." + key) {
+			*v = math.Max(0, viper.GetFloat64("ranking.weights."+key))
+		}
+	}
+	return w
+}
+
+// Ranker computes final scores against one profile.
+type Ranker struct {
+	profile  database.Profile
+	weights  Weights
+	halfLife time.Duration
+	now      time.Time
+	level    string
+	places   *geo.Query
+}
+
+// NewRanker ranks against profile with the configured weights and
+// ranking.stale_half_life. Without a half-life, age is not considered.
+func NewRanker(profile database.Profile, now time.Time) *Ranker {
+	return NewRankerWithWeights(profile, LoadWeights(), freshness.HalfLife(), now)
+}
+
+// NewRankerWithWeights ranks against profile with the given weights and
+// freshness half-life.
+func NewRankerWithWeights(profile database.Profile, weights Weights, halfLife time.Duration, now time.Time) *Ranker {
+	r := &Ranker{profile: profile, weights: weights, halfLife: halfLife, now: now}
+
+	r.level = h1b.LevelForYears(profile.ExperienceYears)
+	if profile.ExperienceLevel != nil {
+		if _, ok := levels[*profile.ExperienceLevel]; ok {
+			r.level = *profile.ExperienceLevel
+		}
+	}
+
+	var locations []string
+	_ = json.Unmarshal([]byte(profile.PreferredLocations), &locations)
+	if len(locations) > 0 {
+		q := geo.ParseQuery(locations)
+		r.places = &q
+	}
+	return r
+}
+
+// levels orders experience levels by seniority.
+var levels = map[string]int{"intern": 0, "entry": 1, "mid": 2, "senior": 3, "staff": 4, "lead": 4}
+
+// Levels lists the experience levels a profile can be set to.
+var Levels = []string{"intern", "entry", "mid", "senior", "staff", "lead"}
+
+// seniorityScores is what a job one, two, ... levels away from the
+// candidate's is worth.
+var seniorityScores = []float64{100, 60, 20}
+
+// Rank computes a job's final score and its breakdown. company may be nil
+// when unknown. Jobs without a skill score cannot be ranked.
+func (r *Ranker) Rank(job database.Job, company *database.Company) (float64, []database.ScoreComponent, bool) {
+	if job.SkillScore == nil {
+		return 0, nil, false
+	}
+
+	parts := []database.ScoreComponent{{Name: ComponentSkill, Score: *job.SkillScore, Weight: r.weights.Skill}}
+	if score, detail, ok := r.seniority(job); ok {
+		parts = append(parts, database.ScoreComponent{Name: ComponentSeniority, Score: score, Weight: r.weights.Seniority, Detail: detail})
+	}
+	if score, detail, ok := r.location(job); ok {
+		parts = append(parts, database.ScoreComponent{Name: ComponentLocation, Score: score, Weight: r.weights.Location, Detail: detail})
+	}
+	if score, detail, ok := r.freshness(job); ok {
+		parts = append(parts, database.ScoreComponent{Name: ComponentFreshness, Score: score, Weight: r.weights.Freshness, Detail: detail})
+	}
+
+	total := 0.0
+	for _, p := range parts {
+		total += p.Weight
+	}
+	if total <= 0 {
+		// Every blended weight is zero: rank on skill alone.
+		parts = parts[:1]
+		parts[0].Weight, total = 1, 1
+	}
+
+	final := 0.0
+	for i := range parts {
+		parts[i].Weight /= total
+		parts[i].Points = parts[i].Weight * parts[i].Score
+		final += parts[i].Points
+	}
+
+	if company != nil && r.profile.VisaRequired {
+		adj := h1b.ScoreH1B(job, *company, r.profile)
+		parts = append(parts, database.ScoreComponent{Name: ComponentH1B, Score: adj.Delta, Points: adj.Delta, Detail: adj.Reason})
+		final += adj.Delta
+	}
+	return clamp(final), parts, true
+}
+
+func (r *Ranker) seniority(job database.Job) (float64, string, bool) {
+	if job.ExperienceLevel == nil {
+		return 0, "", false
+	}
+	want, ok := levels[*job.ExperienceLevel]
+	if !ok {
+		return 0, "", false
+	}
+	have := levels[r.level]
+	distance := want - have
+	if distance < 0 {
+		distance = -distance
+	}
+	score := 0.0
+	if distance < len(seniorityScores) {
+		score = seniorityScores[distance]
+	}
+	return score, fmt.Sprintf("%s role, you are %s", *job.ExperienceLevel, r.level), true
+}
+
+func (r *Ranker) location(job database.Job) (float64, string, bool) {
+	if r.places == nil {
+		return 0, "", false
+	}
+	raw := ""
+	if job.Location != nil {
+		raw = *job.Location
+	}
+	places := geo.PlacesOf(job)
+	if raw == "" && len(places) == 0 && !job.Remote {
+		// Nothing to judge the location by.
+		return 0, "", false
+	}
+	if term, ok := r.places.Match(places, raw); ok {
+		return 100, "matches " + term, true
+	}
+	return 0, "outside your preferred locations", true
+}
+
+func (r *Ranker) freshness(job database.Job) (float64, string, bool) {
+	if r.halfLife <= 0 {
+		return 0, "", false
+	}
+	age, ok := freshness.Age(job, r.now)
+	if !ok {
+		return 0, "", false
+	}
+	return 100 * freshness.Factor(age, r.halfLife), "posted " + freshness.FormatAge(age) + " ago", true
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(100, v))
+}
+
+// Current is a job's final score with its freshness component brought up
+// to now, since the stored score ages along with the posting. Jobs not yet
+// ranked fall back to their skill score.
+func Current(job database.Job, halfLife time.Duration, now time.Time) (float64, bool) {
+	if job.FinalScore == nil {
+		if job.SkillScore == nil {
+			return 0, false
+		}
+		return *job.SkillScore, true
+	}
+	score := *job.FinalScore
+	for _, c := range job.ScoreBreakdown {
+		if c.Name != ComponentFreshness || halfLife <= 0 {
+			continue
+		}
+		if age, ok := freshness.Age(job, now); ok {
+			score += c.Weight * (100*freshness.Factor(age, halfLife) - c.Score)
+		}
+	}
+	return clamp(score), true
+}
+
+// Sort keys accepted by Sort.
+const (
+	SortRank   = "rank"
+	SortSkill  = "skill"
+	SortPosted = "posted"
+)
+
+// Sort orders jobs best first by key: the current final score ("rank", the
+// default), the skill score ("skill"), or newest posting first ("posted").
+// Jobs without a value for the key come last.
+func Sort(jobs []database.Job, key string, now time.Time) ([]database.Job, error) {
+	halfLife := freshness.HalfLife()
+	value := func(j database.Job) (float64, bool) { return Current(j, halfLife, now) }
+	switch strings.ToLower(key) {
+	case "", SortRank:
+	case SortSkill:
+		value = func(j database.Job) (float64, bool) {
+			if j.SkillScore == nil {
+				return 0, false
+			}
+			return *j.SkillScore, true
+		}
+	case SortPosted:
+		value = func(j database.Job) (float64, bool) {
+			t, ok := freshness.PostedAt(j)
+			return float64(t.Unix()), ok
+		}
+	default:
+		return nil, fmt.Errorf("unknown sort %q: use %s, %s or %s", key, SortRank, SortSkill, SortPosted)
+	}
+
+	type keyed struct {
+		v  float64
+		ok bool
+	}
+	keys := make(map[string]keyed, len(jobs))
+	for _, j := range jobs {
+		v, ok := value(j)
+		keys[j.ID] = keyed{v, ok}
+	}
+	sort.SliceStable(jobs, func(a, b int) bool {
+		ka, kb := keys[jobs[a].ID], keys[jobs[b].ID]
+		if ka.ok != kb.ok {
+			return ka.ok
+		}
+		return ka.v > kb.v
+	})
+	return jobs, nil
+}
+
+// Update ranks every scored job, open or closed, against profile and
+// stores the results. It returns how many jobs were ranked.
+func Update(db *database.DB, profile database.Profile) (int, error) {
+	jobs, err := db.QueryJobs(database.JobQuery{IncludeClosed: true})
+	if err != nil {
+		return 0, fmt.Errorf("listing jobs: %w", err)
+	}
+	companies, err := db.ListCompanies()
+	if err != nil {
+		return 0, fmt.Errorf("listing companies: %w", err)
+	}
+	byID := make(map[string]*database.Company, len(companies))
+	for i := range companies {
+		byID[companies[i].ID] = &companies[i]
+	}
+
+	r := NewRanker(profile, time.Now())
+	ranked := 0
+	for _, j := range jobs {
+		score, breakdown, ok := r.Rank(j, byID[j.CompanyID])
+		if !ok {
+			continue
+		}
+		if err := db.UpdateJobFinalScore(j.ID, score, breakdown); err != nil {
+			return ranked, fmt.Errorf("storing final score: %w", err)
+		}
+		ranked++
+	}
+	return ranked, nil
+}
+
+// Describe renders a breakdown on one line, e.g.
+// "skill 80×0.60 + seniority 100×0.20 + h1b +15".
+func Describe(breakdown []database.ScoreComponent) string {
+	parts := make([]string, 0, len(breakdown))
+	for _, c := range breakdown {
+		if c.Weight == 0 {
+			parts = append(parts, fmt.Sprintf("%s %+.0f", c.Name, c.Points))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %.0f×%.2f", c.Name, c.Score, c.Weight))
+	}
+	return strings.Join(parts, " + ")
+}
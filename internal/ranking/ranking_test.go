@@ -0,0 +1,102 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestRank(t *testing.T) {
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	profile := database.Profile{ExperienceYears: 1, PreferredLocations: `["US"]`, VisaRequired: true}
+	r := NewRankerWithWeights(profile, DefaultWeights, 14*24*time.Hour, now)
+
+	job := database.Job{
+		SkillScore:      ptr(80.0),
+		ExperienceLevel: ptr("entry"),
+		Location:        ptr("New York, NY"),
+		PostedAt:        ptr(now),
+	}
+	sponsor := &database.Company{SponsorsH1b: true, H1bApprovalRate: ptr(95.0)}
+
+	score, breakdown, ok := r.Rank(job, sponsor)
+	if !ok {
+		t.Fatal("scored job not ranked")
+	}
+	// 80×0.6 + 100×0.15 + 100×0.15 + 100×0.1 = 88, plus 15 for the sponsor.
+	if score != 100 {
+		t.Errorf("score = %.1f, want 100 (clamped); breakdown = %+v", score, breakdown)
+	}
+	var points float64
+	for _, c := range breakdown {
+		points += c.Points
+	}
+	if math.Abs(points-103) > 1e-9 {
+		t.Errorf("breakdown adds up to %.1f, want 103", points)
+	}
+
+	// A senior role elsewhere at a non-sponsor scores well below.
+	job.ExperienceLevel, job.Location = ptr("senior"), ptr("London, UK")
+	score, breakdown, _ = r.Rank(job, &database.Company{})
+	if want := 80*0.6 + 20*0.15 + 0 + 100*0.1; math.Abs(score-want) > 1e-9 {
+		t.Errorf("score = %.1f, want %.1f; breakdown = %+v", score, want, breakdown)
+	}
+
+	if _, _, ok := r.Rank(database.Job{}, nil); ok {
+		t.Error("unscored job ranked")
+	}
+}
+
+func TestRankRedistributesMissingComponents(t *testing.T) {
+	// No preferred locations, no level on the job, no half-life: only the
+	// skill score is left to rank on.
+	r := NewRankerWithWeights(database.Profile{}, DefaultWeights, 0, time.Now())
+	score, breakdown, _ := r.Rank(database.Job{SkillScore: ptr(72.0)}, nil)
+	if score != 72 || len(breakdown) != 1 || breakdown[0].Weight != 1 {
+		t.Errorf("score = %.1f, breakdown = %+v; want 72 from skill alone", score, breakdown)
+	}
+}
+
+func TestCurrentAndSort(t *testing.T) {
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	halfLife := 14 * 24 * time.Hour
+	ago := func(days int) *time.Time { t := now.Add(-time.Duration(days) * 24 * time.Hour); return &t }
+
+	r := NewRankerWithWeights(database.Profile{}, Weights{Skill: 50, Freshness: 50}, halfLife, now.Add(-60*24*time.Hour))
+	rank := func(id string, skill float64, posted *time.Time) database.Job {
+		j := database.Job{ID: id, SkillScore: ptr(skill), PostedAt: posted}
+		score, breakdown, _ := r.Rank(j, nil)
+		j.FinalScore, j.ScoreBreakdown = &score, breakdown
+		return j
+	}
+	// Ranked 60 days ago, when every posting below was brand new.
+	stale := rank("stale", 90, ago(60))
+	fresh := rank("fresh", 70, ago(1))
+
+	if v, _ := Current(stale, halfLife, now); math.Abs(v-(45+50*math.Pow(0.5, 60.0/14))) > 1e-9 {
+		t.Errorf("Current(stale) = %.2f", v)
+	}
+	if v, _ := Current(database.Job{SkillScore: ptr(55.0)}, halfLife, now); v != 55 {
+		t.Errorf("unranked job = %.1f, want its skill score", v)
+	}
+
+	jobs := []database.Job{stale, {ID: "unscored"}, fresh}
+	if _, err := Sort(jobs, "rank", now); err != nil {
+		t.Fatal(err)
+	}
+	// Sort reads the half-life from config, where none is set: the stored
+	// scores stand, and the better skill match wins.
+	if jobs[0].ID != "stale" || jobs[2].ID != "unscored" {
+		t.Errorf("order = %s, %s, %s", jobs[0].ID, jobs[1].ID, jobs[2].ID)
+	}
+	if _, err := Sort(jobs, "posted", now); err != nil || jobs[0].ID != "fresh" {
+		t.Errorf("posted order starts with %s, %v", jobs[0].ID, err)
+	}
+	if _, err := Sort(jobs, "salary", now); err == nil {
+		t.Error("unknown sort key accepted")
+	}
+}
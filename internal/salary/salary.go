@@ -0,0 +1,279 @@
+// Package salary extracts pay ranges from posting text and platform
+// compensation summaries.
+package salary
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+)
+
+// Periods a Range can be quoted in.
+const (
+	Hour  = "hour"
+	Day   = "day"
+	Week  = "week"
+	Month = "month"
+	Year  = "year"
+)
+
+// Range is a pay range. Max equals Min when the posting quotes one figure.
+type Range struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+	Period   string  `json:"period"`
+}
+
+// periodsPerYear converts a period's amount to an annual one, assuming a
+// 40-hour week.
+var periodsPerYear = map[string]float64{
+	Hour:  2080,
+	Day:   260,
+	Week:  52,
+	Month: 12,
+	Year:  1,
+}
+
+// Annual returns the range scaled to a yearly amount.
+func (r Range) Annual() (lo, hi float64) {
+	f := periodsPerYear[r.Period]
+	if f == 0 {
+		f = 1
+	}
+	return r.Min * f, r.Max * f
+}
+
+// FromJob returns the range stored on a job, if it has one.
+func FromJob(j database.Job) (Range, bool) {
+	if j.SalaryMin == nil || j.SalaryMax == nil || j.SalaryCurrency == nil || j.SalaryPeriod == nil {
+		return Range{}, false
+	}
+	return Range{Min: *j.SalaryMin, Max: *j.SalaryMax, Currency: *j.SalaryCurrency, Period: *j.SalaryPeriod}, true
+}
+
+var currencySymbols = map[string]string{"USD": "$", "EUR": "€", "GBP": "£"}
+
+var periodSuffixes = map[string]string{Hour: "/hr", Day: "/day", Week: "/wk", Month: "/mo", Year: "/yr"}
+
+// String renders the range compactly, e.g. "$150K–200K/yr" or "CAD 45–60/hr".
+func (r Range) String() string {
+	prefix := r.Currency + " "
+	if sym, ok := currencySymbols[r.Currency]; ok {
+		prefix = sym
+	}
+	text := prefix + formatAmount(r.Min)
+	if r.Max != r.Min {
+		text += "–" + formatAmount(r.Max)
+	}
+	return text + periodSuffixes[r.Period]
+}
+
+func formatAmount(v float64) string {
+	if v >= 1000 {
+		return strconv.FormatFloat(v/1000, 'f', -1, 64) + "K"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+const (
+	symbolPattern = `US\$|CA\$|C\$|AU\$|A\$|\$|€|£`
+	codePattern   = `usd|eur|gbp|cad|aud|chf|inr|sgd`
+	numberPattern = `\d{1,3}(?:[,.]\d{3})+(?:\.\d{1,2})?|\d+(?:\.\d{1,2})?`
+)
+
+var (
+	amountRe = regexp.MustCompile(`(?i)(` + symbolPattern + `|\b(?:` + codePattern + `)\s?)?\s?(` + numberPattern + `)\s?(k\b)?` +
+		`(?:\s?(?:-|–|—|to)\s?(` + symbolPattern + `|\b(?:` + codePattern + `)\s?)?\s?(` + numberPattern + `)\s?(k\b)?)?` +
+		`(?:\s?\b(` + codePattern + `)\b)?`)
+	largeUnitRe = regexp.MustCompile(`(?i)^\s*(?:m\b|mm\b|b\b|bn\b|million|billion)`)
+	contextRe   = regexp.MustCompile(`(?i)\b(?:salary|pay|compensation|base|range|rate|wage|ote|earn)`)
+	tagRe       = regexp.MustCompile(`<[^>]*>`)
+
+	periodRes = []struct {
+		period string
+		re     *regexp.Regexp
+	}{
+		{Hour, regexp.MustCompile(`(?i)\b(?:hour|hr|hourly)\b`)},
+		{Year, regexp.MustCompile(`(?i)\b(?:year|yr|annum|annual|annually|yearly)\b`)},
+		{Month, regexp.MustCompile(`(?i)\b(?:month|mo|monthly)\b`)},
+		{Week, regexp.MustCompile(`(?i)\b(?:week|wk|weekly)\b`)},
+		{Day, regexp.MustCompile(`(?i)\b(?:day|daily)\b`)},
+	}
+)
+
+var currencyBySymbol = map[string]string{
+	"$": "USD", "US$": "USD", "CA$": "CAD", "C$": "CAD", "AU$": "AUD", "A$": "AUD", "€": "EUR", "£": "GBP",
+}
+
+// Extract returns the pay range from a platform's compensation summary, or
+// failing that from the posting's description.
+func Extract(compensation, description string) (Range, bool) {
+	if r, ok := find(compensation, false); ok {
+		return r, true
+	}
+	return find(description, true)
+}
+
+// Parse finds the first pay range in free text such as a job description.
+func Parse(text string) (Range, bool) {
+	return find(text, true)
+}
+
+// find scans text for currency amounts. In free text a lone figure only
+// counts when it follows wording like "salary" or "pay", since descriptions
+// mention funding rounds and stipends too; a range is taken as is.
+func find(text string, inProse bool) (Range, bool) {
+	if text == "" {
+		return Range{}, false
+	}
+	text = html.UnescapeString(tagRe.ReplaceAllString(html.UnescapeString(text), " "))
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+
+	var single *Range
+	for _, m := range amountRe.FindAllStringSubmatchIndex(text, -1) {
+		group := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return text[m[2*i]:m[2*i+1]]
+		}
+		currency := currencyOf(group(1))
+		if currency == "" {
+			currency = currencyOf(group(4))
+		}
+		// A code after the range says which dollar a bare "$" means:
+		// "$120,000 - $150,000 CAD". CA$ or US$ already say it.
+		if code := strings.ToUpper(group(7)); code != "" && (currency == "" || bareDollar(group(1), group(4))) {
+			currency = code
+		}
+		if currency == "" || largeUnitRe.MatchString(text[m[1]:]) {
+			continue
+		}
+
+		lo, ok := parseAmount(group(2), group(3))
+		if !ok {
+			continue
+		}
+		hi := lo
+		isRange := group(5) != ""
+		if isRange {
+			if hi, ok = parseAmount(group(5), group(6)); !ok {
+				continue
+			}
+			// "$150-200K" scales both ends.
+			if group(3) == "" && group(6) != "" && lo < 1000 && hi >= 1000 {
+				lo *= 1000
+			}
+			if hi < lo {
+				lo, hi = hi, lo
+			}
+		}
+
+		r := Range{Min: lo, Max: hi, Currency: currency, Period: periodNear(text, m[0], m[1], hi)}
+		if !plausible(r) {
+			continue
+		}
+		if isRange {
+			return r, true
+		}
+		before := text[max(0, m[0]-80):m[0]]
+		if single == nil && (!inProse || contextRe.MatchString(before)) {
+			single = &r
+		}
+	}
+	if single != nil {
+		return *single, true
+	}
+	return Range{}, false
+}
+
+// bareDollar reports whether the range's symbols are a plain "$" that
+// names no country.
+func bareDollar(symbols ...string) bool {
+	bare := false
+	for _, s := range symbols {
+		switch strings.TrimSpace(s) {
+		case "":
+		case "$":
+			bare = true
+		default:
+			return false
+		}
+	}
+	return bare
+}
+
+func currencyOf(s string) string {
+	s = strings.TrimSpace(s)
+	if c, ok := currencyBySymbol[strings.ToUpper(s)]; ok {
+		return c
+	}
+	return strings.ToUpper(s)
+}
+
+// parseAmount reads a figure written with either "," or "." as the
+// thousands separator, plus an optional "k".
+func parseAmount(num, unit string) (float64, bool) {
+	if i := strings.LastIndexAny(num, ",."); i >= 0 {
+		if len(num)-i-1 == 3 {
+			num = strings.NewReplacer(",", "", ".", "").Replace(num)
+		} else {
+			num = strings.NewReplacer(",", "", ".", "").Replace(num[:i]) + "." + num[i+1:]
+		}
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, false
+	}
+	if unit != "" {
+		v *= 1000
+	}
+	return v, true
+}
+
+// periodNear looks for a pay period just after the amount, then just before
+// it, and otherwise guesses one from the size of the figure.
+func periodNear(text string, start, end int, amount float64) string {
+	after := text[end:min(end+30, len(text))]
+	before := text[max(0, start-40):start]
+	for _, window := range []string{after, before} {
+		for _, p := range periodRes {
+			if p.re.MatchString(window) {
+				return p.period
+			}
+		}
+	}
+	switch {
+	case amount < 1000:
+		return Hour
+	case amount < 20000:
+		return Month
+	}
+	return Year
+}
+
+func plausible(r Range) bool {
+	lo, hi := r.Annual()
+	return lo >= 10000 && hi <= 5000000
+}
+
+// FormatSummary renders structured platform pay data the way platforms
+// phrase it, e.g. "USD 150000–200000 per year", for Extract to read back.
+func FormatSummary(lo, hi float64, currency, period string) string {
+	if lo == 0 && hi == 0 {
+		return ""
+	}
+	if lo == 0 {
+		lo = hi
+	}
+	if hi == 0 {
+		hi = lo
+	}
+	return fmt.Sprintf("%s %s–%s per %s", strings.ToUpper(currency),
+		strconv.FormatFloat(lo, 'f', -1, 64), strconv.FormatFloat(hi, 'f', -1, 64), period)
+}
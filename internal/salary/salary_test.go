@@ -0,0 +1,65 @@
+package salary
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Range
+		ok   bool
+	}{
+		{"us range", "The base salary range for this role is $150,000 - $200,000 per year.", Range{150000, 200000, "USD", Year}, true},
+		{"k suffix", "Compensation: $150K–$200K", Range{150000, 200000, "USD", Year}, true},
+		{"shared k", "Pay: $150-200k plus equity", Range{150000, 200000, "USD", Year}, true},
+		{"hourly", "Interns earn $45 - $60/hour.", Range{45, 60, "USD", Hour}, true},
+		{"code suffix", "Salary 120,000 to 140,000 CAD annually", Range{120000, 140000, "CAD", Year}, true},
+		{"code suffix after dollar sign", "Salary range: $120,000 - $150,000 CAD per year", Range{120000, 150000, "CAD", Year}, true},
+		{"specific symbol beats code", "Pay: US$90,000 - US$110,000 CAD-equivalent", Range{90000, 110000, "USD", Year}, true},
+		{"euro separators", "Gehalt: €60.000 - €75.000 pro Jahr", Range{60000, 75000, "EUR", Year}, true},
+		{"pound single", "Base salary of £85,000 depending on experience", Range{85000, 85000, "GBP", Year}, true},
+		{"html", "&lt;p&gt;Salary range: &lt;strong&gt;$120,000&amp;nbsp;—&amp;nbsp;$160,000 USD&lt;/strong&gt;&lt;/p&gt;", Range{120000, 160000, "USD", Year}, true},
+		{"monthly", "Salary: €4.500 - €5.500 per month", Range{4500, 5500, "EUR", Month}, true},
+		{"funding is not pay", "We raised $50 million and have $20M ARR.", Range{}, false},
+		{"lone figure without context", "Enjoy a $1,500 learning budget and $500 home office stipend.", Range{}, false},
+		{"years of experience", "3-5 years of experience with Go", Range{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.text)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestExtractPrefersPlatformSummary(t *testing.T) {
+	got, ok := Extract(FormatSummary(140000, 180000, "usd", Year), "Salary: $90,000 - $100,000")
+	want := Range{140000, 180000, "USD", Year}
+	if !ok || got != want {
+		t.Errorf("Extract = %+v, %v; want %+v", got, ok, want)
+	}
+
+	// A platform summary may be a lone figure with no "salary" wording.
+	got, ok = Extract("$95K", "")
+	if !ok || got.Min != 95000 || got.Max != 95000 {
+		t.Errorf("Extract single summary = %+v, %v", got, ok)
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	tests := []struct {
+		r    Range
+		want string
+	}{
+		{Range{150000, 200000, "USD", Year}, "$150K–200K/yr"},
+		{Range{45, 60, "CAD", Hour}, "CAD 45–60/hr"},
+		{Range{85000, 85000, "GBP", Year}, "£85K/yr"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type AshbyScraper struct {
+	client *http.Client
+}
+
+func NewAshbyScraper() *AshbyScraper {
+	return &AshbyScraper{
+		client: newHTTPClient("ashby"),
+	}
+}
+
+func (a *AshbyScraper) Name() string {
+	return "ashby"
+}
+
+// Ashby posting API response structure
+type ashbyJobBoard struct {
+	Jobs []payload[ashbyJob] `json:"jobs"`
+}
+
+type ashbyJob struct {
+	ID               string             `json:"id"`
+	Title            string             `json:"title"`
+	Department       string             `json:"department"`
+	Team             string             `json:"team"`
+	EmploymentType   string             `json:"employmentType"`
+	Location         string             `json:"location"`
+	IsRemote         bool               `json:"isRemote"`
+	WorkplaceType    string             `json:"workplaceType"`
+	IsListed         bool               `json:"isListed"`
+	DescriptionPlain string             `json:"descriptionPlain"`
+	PublishedAt      string             `json:"publishedAt"`
+	JobURL           string             `json:"jobUrl"`
+	Compensation     *ashbyCompensation `json:"compensation"`
+}
+
+type ashbyCompensation struct {
+	TierSummary   string `json:"compensationTierSummary"`
+	SalarySummary string `json:"scrapeableCompensationSalarySummary"`
+}
+
+func (a *AshbyScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	jobs, _, err := a.FetchJobsIfChanged(ctx, slug, Validators{})
+	return jobs, err
+}
+
+// FetchJobsIfChanged implements ConditionalScraper.
+func (a *AshbyScraper) FetchJobsIfChanged(ctx context.Context, slug string, prev Validators) ([]RawJob, Validators, error) {
+	url := fmt.Sprintf("https://api.ashbyhq.com/posting-api/job-board/%s?includeCompensation=true", slug)
+	resp, next, err := getIfChanged(ctx, a.client, url, prev)
+	if err != nil {
+		return nil, next, fmt.Errorf("fetching ashby postings: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, next, &StatusError{Source: "ashby API", StatusCode: resp.StatusCode}
+	}
+
+	var board ashbyJobBoard
+	if err := json.NewDecoder(resp.Body).Decode(&board); err != nil {
+		return nil, next, fmt.Errorf("decoding ashby response: %w", err)
+	}
+
+	jobs := make([]RawJob, 0, len(board.Jobs))
+	for _, pj := range board.Jobs {
+		if !pj.V.IsListed {
+			continue
+		}
+		rj := ashbyRawJob(pj.V)
+		rj.Raw = pj.Raw
+		jobs = append(jobs, rj)
+	}
+
+	return jobs, next, nil
+}
+
+func ashbyRawJob(j ashbyJob) RawJob {
+	var postedAt *time.Time
+	if j.PublishedAt != "" {
+		if t, err := time.Parse(time.RFC3339, j.PublishedAt); err == nil {
+			postedAt = &t
+		}
+	}
+
+	compensation := ""
+	if j.Compensation != nil {
+		compensation = j.Compensation.SalarySummary
+		if compensation == "" {
+			compensation = j.Compensation.TierSummary
+		}
+	}
+
+	department := j.Department
+	if department == "" {
+		department = j.Team
+	}
+
+	remote := j.IsRemote || strings.EqualFold(j.WorkplaceType, "remote") ||
+		strings.Contains(strings.ToLower(j.Location), "remote")
+
+	return RawJob{
+		ExternalID:     j.ID,
+		Title:          j.Title,
+		Description:    j.DescriptionPlain,
+		Location:       j.Location,
+		Remote:         remote,
+		Department:     department,
+		URL:            j.JobURL,
+		PostedAt:       postedAt,
+		EmploymentType: j.EmploymentType,
+		Compensation:   compensation,
+	}
+}
+
+// DecodePayload implements PayloadDecoder.
+func (a *AshbyScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("ashby", raw, ashbyRawJob)
+}
+
+// SlugFromURL recognises jobs.ashbyhq.com/<slug> and the posting API URL.
+func (a *AshbyScraper) SlugFromURL(u *url.URL) (string, bool) {
+	segs := pathSegments(u)
+	switch strings.ToLower(u.Hostname()) {
+	case "jobs.ashbyhq.com":
+		if len(segs) > 0 {
+			return segs[0], true
+		}
+	case "api.ashbyhq.com":
+		if len(segs) > 2 && segs[0] == "posting-api" && segs[1] == "job-board" {
+			return segs[2], true
+		}
+	}
+	return "", false
+}
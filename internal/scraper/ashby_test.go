@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAshbyFetchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	scraper := NewAshbyScraper()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Ashby hosts its own careers page on Ashby
+	jobs, err := scraper.FetchJobs(ctx, "ashby")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+
+	t.Logf("Found %d jobs from Ashby/ashby", len(jobs))
+
+	if len(jobs) == 0 {
+		t.Log("Warning: no jobs returned")
+		return
+	}
+
+	j := jobs[0]
+	if j.ExternalID == "" {
+		t.Error("ExternalID is empty")
+	}
+	if j.Title == "" {
+		t.Error("Title is empty")
+	}
+	if j.URL == "" {
+		t.Error("URL is empty")
+	}
+
+	t.Logf("Sample job: %s — %s (%s) [%s] %s", j.Title, j.Location, j.URL, j.EmploymentType, j.Compensation)
+}
+
+func TestAshbyDecodePayload(t *testing.T) {
+	raw := []byte(`{"id":"f1","title":"Backend Engineer","department":"","team":"Platform","employmentType":"FullTime",
+		"location":"New York","workplaceType":"Remote","isListed":true,"descriptionPlain":"Go and SQL",
+		"publishedAt":"2026-03-01T12:00:00Z","jobUrl":"https://jobs.ashbyhq.com/acme/f1",
+		"compensation":{"compensationTierSummary":"$120K – $150K • Offers Equity","scrapeableCompensationSalarySummary":"$120K - $150K"}}`)
+
+	j, err := NewAshbyScraper().DecodePayload(raw)
+	if err != nil {
+		t.Fatalf("DecodePayload error: %v", err)
+	}
+	if j.ExternalID != "f1" || !j.Remote || j.EmploymentType != "FullTime" || j.Department != "Platform" {
+		t.Errorf("decoded %+v", j)
+	}
+	if j.Compensation != "$120K - $150K" {
+		t.Errorf("Compensation = %q, want the salary summary", j.Compensation)
+	}
+	if j.PostedAt == nil || !j.PostedAt.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("PostedAt = %v", j.PostedAt)
+	}
+	if string(j.Raw) != string(raw) {
+		t.Error("Expected Raw to be the stored payload")
+	}
+
+	// Without a salary summary the tier summary is used.
+	j, _ = NewAshbyScraper().DecodePayload([]byte(`{"id":"f2","compensation":{"compensationTierSummary":"€70K – €90K"}}`))
+	if j.Compensation != "€70K – €90K" {
+		t.Errorf("Compensation = %q, want the tier summary", j.Compensation)
+	}
+
+	if _, err := NewAshbyScraper().DecodePayload([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for a malformed payload")
+	}
+}
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// ErrNotModified is returned by ConditionalScraper when the board answered
+// 304 to a conditional request.
+var ErrNotModified = errors.New("board not modified")
+
+// Validators are the HTTP cache validators from a board's last fetch.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// ConditionalScraper is implemented by scrapers whose board is a single
+// request, so it can be made conditional on the previous fetch's validators.
+type ConditionalScraper interface {
+	Scraper
+	FetchJobsIfChanged(ctx context.Context, slug string, prev Validators) ([]RawJob, Validators, error)
+}
+
+// getIfChanged GETs url with If-None-Match / If-Modified-Since taken from
+// prev. A 304 is returned as ErrNotModified with prev unchanged; any other
+// response is handed back with its validators for the caller to check.
+func getIfChanged(ctx context.Context, client *http.Client, url string, prev Validators) (*http.Response, Validators, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, prev, fmt.Errorf("creating request: %w", err)
+	}
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, prev, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		return nil, prev, ErrNotModified
+	}
+	return resp, Validators{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}, nil
+}
+
+// ContentHash fingerprints a board's postings independent of the order the
+// platform returned them in.
+func ContentHash(jobs []RawJob) string {
+	sorted := make([]RawJob, len(jobs))
+	copy(sorted, jobs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ExternalID < sorted[j].ExternalID })
+
+	h := sha256.New()
+	_ = json.NewEncoder(h).Encode(sorted)
+	return hex.EncodeToString(h.Sum(nil))
+}
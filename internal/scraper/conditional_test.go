@@ -0,0 +1,51 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetIfChanged(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Fri, 01 Mar 2024 12:00:00 GMT")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	resp, v, err := getIfChanged(context.Background(), srv.Client(), srv.URL, Validators{})
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	_ = resp.Body.Close()
+	if v.ETag != `"v1"` || v.LastModified == "" {
+		t.Errorf("validators = %+v", v)
+	}
+
+	_, again, err := getIfChanged(context.Background(), srv.Client(), srv.URL, v)
+	if !errors.Is(err, ErrNotModified) {
+		t.Fatalf("second fetch err = %v, want ErrNotModified", err)
+	}
+	if again != v {
+		t.Errorf("validators changed on 304: %+v", again)
+	}
+}
+
+func TestContentHashIgnoresOrder(t *testing.T) {
+	a := []RawJob{{ExternalID: "1", Title: "Backend"}, {ExternalID: "2", Title: "Frontend"}}
+	b := []RawJob{a[1], a[0]}
+	if ContentHash(a) != ContentHash(b) {
+		t.Error("hash should not depend on order")
+	}
+	b[0].Title = "Frontend Engineer"
+	if ContentHash(a) == ContentHash(b) {
+		t.Error("hash should change when a posting changes")
+	}
+}
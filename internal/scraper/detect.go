@@ -0,0 +1,251 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// detectMaxPostingProbes caps how many posting links are fetched when looking
+// for JSON-LD on a career page that has none itself.
+const detectMaxPostingProbes = 3
+
+// SlugDetector is implemented by scrapers that recognise their own board
+// URLs, e.g. jobs.lever.co/<slug>.
+type SlugDetector interface {
+	Scraper
+	SlugFromURL(u *url.URL) (string, bool)
+}
+
+// Detection is the platform and slug a careers URL resolved to.
+type Detection struct {
+	Platform  string `json:"platform"`
+	Slug      string `json:"slug"`
+	CareerURL string `json:"career_url"`
+}
+
+// embeddedURLRE finds absolute URLs inside inline scripts, where ATS embed
+// snippets usually live.
+var embeddedURLRE = regexp.MustCompile(`https?://[^\s"'<>\\)]+`)
+
+// Detect works out which registered platform serves careerURL. The URL itself
+// is tried first; otherwise the page is fetched and its iframes, scripts and
+// links are checked for a known ATS board. Pages carrying schema.org
+// JobPosting data fall back to the jsonld platform.
+func (r *Registry) Detect(ctx context.Context, careerURL string) (*Detection, error) {
+	return r.detect(ctx, careerURL, newHTTPClient("detect"))
+}
+
+// DetectPublic is Detect for URLs from untrusted callers, such as the API
+// server: it refuses to fetch loopback, private and link-local addresses,
+// whether named by the URL or reached by a redirect.
+func (r *Registry) DetectPublic(ctx context.Context, careerURL string) (*Detection, error) {
+	client := newHTTPClient("detect")
+	client.Transport = &publicOnlyTransport{base: client.Transport}
+	return r.detect(ctx, careerURL, client)
+}
+
+func (r *Registry) detect(ctx context.Context, careerURL string, client *http.Client) (*Detection, error) {
+	if !strings.Contains(careerURL, "://") {
+		careerURL = "https://" + careerURL
+	}
+	u, err := url.Parse(careerURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q", careerURL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q: use http or https", u.Scheme)
+	}
+
+	if d := r.detectURL(u); d != nil {
+		d.CareerURL = u.String()
+		return d, nil
+	}
+
+	doc, final, err := fetchHTML(ctx, client, u.String())
+	if err != nil {
+		return nil, err
+	}
+	if d := r.detectURL(final); d != nil {
+		d.CareerURL = u.String()
+		return d, nil
+	}
+
+	for _, ref := range embeddedURLs(doc, final) {
+		if d := r.detectURL(ref); d != nil {
+			d.CareerURL = u.String()
+			return d, nil
+		}
+	}
+
+	if _, err := r.Get("jsonld"); err == nil && r.hasJobPostings(ctx, client, doc, final) {
+		return &Detection{Platform: "jsonld", Slug: SlugFromCareerURL(u.String()), CareerURL: u.String()}, nil
+	}
+
+	return nil, fmt.Errorf("no supported platform found at %s; add it with --platform and --slug, or describe the page with the html platform", u)
+}
+
+// publicOnlyTransport refuses requests to anything but public http(s)
+// addresses. The client sends each redirect through it too.
+type publicOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *publicOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := checkPublicURL(req.Context(), req.URL); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// checkPublicURL reports an error unless u is http(s) and every address its
+// host resolves to is public.
+func checkPublicURL(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("refusing to fetch %s: unsupported scheme", u.Redacted())
+	}
+	host := u.Hostname()
+	var addrs []netip.Addr
+	if a, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{a}
+	} else {
+		ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", host, err)
+		}
+		addrs = ips
+	}
+	for _, a := range addrs {
+		if !isPublicAddr(a) {
+			return fmt.Errorf("refusing to fetch %s: %s is not a public address", u.Redacted(), a)
+		}
+	}
+	return nil
+}
+
+// sharedAddressSpace is carrier-grade NAT space (RFC 6598), private in
+// practice though not flagged by netip.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(a netip.Addr) bool {
+	a = a.Unmap()
+	return a.IsGlobalUnicast() && !a.IsPrivate() && !sharedAddressSpace.Contains(a)
+}
+
+func (r *Registry) detectURL(u *url.URL) *Detection {
+	for _, name := range r.sortedPlatforms() {
+		d, ok := r.scrapers[name].(SlugDetector)
+		if !ok {
+			continue
+		}
+		if slug, ok := d.SlugFromURL(u); ok && slug != "" {
+			return &Detection{Platform: name, Slug: slug}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) sortedPlatforms() []string {
+	names := r.Platforms()
+	sort.Strings(names)
+	return names
+}
+
+// hasJobPostings reports whether the page, or one of the first few posting
+// links on it, embeds a schema.org JobPosting.
+func (r *Registry) hasJobPostings(ctx context.Context, client *http.Client, doc *html.Node, base *url.URL) bool {
+	if len(jobPostingsFromDoc(doc, base.String())) > 0 {
+		return true
+	}
+	probes := 0
+	for _, link := range extractLinks(doc, base) {
+		if probes >= detectMaxPostingProbes {
+			break
+		}
+		if link.next || link.url.Host != base.Host || !looksLikePostingPath(link.url.Path) || link.url.Path == base.Path {
+			continue
+		}
+		probes++
+		page, _, err := fetchHTML(ctx, client, link.url.String())
+		if err == nil && len(jobPostingsFromDoc(page, link.url.String())) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedURLs collects the iframe, script and link targets on a page plus
+// any absolute URLs mentioned in inline scripts.
+func embeddedURLs(doc *html.Node, base *url.URL) []*url.URL {
+	var refs []*url.URL
+	add := func(raw string) {
+		if raw == "" {
+			return
+		}
+		if u, err := base.Parse(strings.TrimSpace(raw)); err == nil && u.Host != "" {
+			refs = append(refs, u)
+		}
+	}
+	walkHTML(doc, func(n *html.Node) {
+		if n.Type != html.ElementNode {
+			return
+		}
+		switch n.Data {
+		case "iframe", "script":
+			add(attr(n, "src"))
+			add(attr(n, "data-src"))
+			if n.Data == "script" {
+				for _, m := range embeddedURLRE.FindAllString(textContent(n), -1) {
+					add(m)
+				}
+			}
+		case "a", "link":
+			add(attr(n, "href"))
+		}
+	})
+	return refs
+}
+
+func fetchHTML(ctx context.Context, client *http.Client, pageURL string) (*html.Node, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching %s: %w", pageURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, &StatusError{Source: pageURL, StatusCode: resp.StatusCode}
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", pageURL, err)
+	}
+	return doc, resp.Request.URL, nil
+}
+
+// pathSegments splits a URL path into its non-empty segments.
+func pathSegments(u *url.URL) []string {
+	var segs []string
+	for _, s := range strings.Split(u.Path, "/") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+	return segs
+}
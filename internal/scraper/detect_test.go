@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestDetect_FromURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		platform string
+		slug     string
+	}{
+		{"https://jobs.lever.co/stripe", "lever", "stripe"},
+		{"https://jobs.lever.co/stripe/8c1f-4e2a?lever-source=x", "lever", "stripe"},
+		{"https://boards.greenhouse.io/airbnb/jobs/123", "greenhouse", "airbnb"},
+		{"https://job-boards.greenhouse.io/airbnb", "greenhouse", "airbnb"},
+		{"https://boards.greenhouse.io/embed/job_board?for=airbnb", "greenhouse", "airbnb"},
+		{"jobs.ashbyhq.com/ramp", "ashby", "ramp"},
+		{"https://nvidia.wd5.myworkdayjobs.com/en-US/NVIDIAExternalCareerSite/job/123", "workday", "nvidia.wd5/NVIDIAExternalCareerSite"},
+		{"https://nvidia.wd5.myworkdayjobs.com/NVIDIAExternalCareerSite", "workday", "nvidia.wd5/NVIDIAExternalCareerSite"},
+		{"https://jobs.smartrecruiters.com/Visa/7434", "smartrecruiters", "Visa"},
+		{"https://apply.workable.com/huggingface/", "workable", "huggingface"},
+		{"https://acme.recruitee.com/o/engineer", "recruitee", "acme"},
+	}
+
+	r := NewRegistry()
+	for _, tt := range tests {
+		d, err := r.Detect(context.Background(), tt.url)
+		if err != nil {
+			t.Errorf("Detect(%q): %v", tt.url, err)
+			continue
+		}
+		if d.Platform != tt.platform || d.Slug != tt.slug {
+			t.Errorf("Detect(%q) = %s/%s, want %s/%s", tt.url, d.Platform, d.Slug, tt.platform, tt.slug)
+		}
+	}
+}
+
+func TestDetect_FromMarkup(t *testing.T) {
+	pages := map[string]string{
+		"/iframe":           `<html><body><iframe src="https://boards.greenhouse.io/embed/job_board?for=acme&b=https%3A%2F%2Facme.com"></iframe></body></html>`,
+		"/script":           `<html><body><div id="grnhse_app"></div><script src="https://boards.greenhouse.io/embed/job_board/js?for=acme"></script></body></html>`,
+		"/inline":           `<html><body><script>window.board = "https://jobs.lever.co/acme";</script></body></html>`,
+		"/careers":          `<html><body><a href="https://www.greenhouse.io/">Powered by Greenhouse</a><a href="/careers/engineer">Engineer</a></body></html>`,
+		"/careers/engineer": postingPage(`{"@type": "JobPosting", "title": "Engineer"}`),
+		"/nothing":          `<html><body><p>No jobs here</p></body></html>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path     string
+		platform string
+		slug     string
+	}{
+		{"/iframe", "greenhouse", "acme"},
+		{"/script", "greenhouse", "acme"},
+		{"/inline", "lever", "acme"},
+		{"/careers", "jsonld", "127.0.0.1"},
+	}
+
+	r := NewRegistry()
+	for _, tt := range tests {
+		d, err := r.Detect(context.Background(), srv.URL+tt.path)
+		if err != nil {
+			t.Errorf("Detect(%s): %v", tt.path, err)
+			continue
+		}
+		if d.Platform != tt.platform || d.Slug != tt.slug || d.CareerURL != srv.URL+tt.path {
+			t.Errorf("Detect(%s) = %+v, want %s/%s", tt.path, d, tt.platform, tt.slug)
+		}
+	}
+
+	if _, err := r.Detect(context.Background(), srv.URL+"/nothing"); err == nil {
+		t.Error("expected error for page with no known platform")
+	}
+}
+
+func TestDetectPublic_RefusesInternalAddresses(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = fmt.Fprint(w, `<html><body><iframe src="https://boards.greenhouse.io/embed/job_board?for=acme"></iframe></body></html>`)
+	}))
+	defer srv.Close()
+
+	r := NewRegistry()
+	for _, u := range []string{
+		srv.URL + "/careers",
+		"http://localhost/careers",
+		"http://10.0.0.5/careers",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]/careers",
+		"file:///etc/passwd",
+	} {
+		if d, err := r.DetectPublic(context.Background(), u); err == nil {
+			t.Errorf("DetectPublic(%s) = %+v, want it refused", u, d)
+		}
+	}
+	if hits != 0 {
+		t.Errorf("server was fetched %d times, want never", hits)
+	}
+
+	// Board URLs resolve without a fetch, so they still work.
+	if d, err := r.DetectPublic(context.Background(), "https://jobs.lever.co/stripe"); err != nil || d.Slug != "stripe" {
+		t.Errorf("DetectPublic(lever) = %+v, %v", d, err)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"192.168.1.10":    false,
+		"172.16.0.1":      false,
+		"100.64.0.1":      false,
+		"169.254.169.254": false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.1": false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	} {
+		if got := isPublicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
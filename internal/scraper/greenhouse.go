@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 	"strings"
 )
@@ -15,7 +16,7 @@ type GreenhouseScraper struct {
 
 func NewGreenhouseScraper() *GreenhouseScraper {
 	return &GreenhouseScraper{
-		client: &http.Client{Timeout: 30 * time.Second},
+		client: newHTTPClient("greenhouse"),
 	}
 }
 
@@ -25,7 +26,7 @@ func (g *GreenhouseScraper) Name() string {
 
 // Greenhouse API response structure
 type greenhouseJobList struct {
-	JobList []greenhouseJob `json:"jobs"`
+	JobList []payload[greenhouseJob] `json:"jobs"`
 }
 
 type greenhouseJob struct {
@@ -34,6 +35,7 @@ type greenhouseJob struct {
 	URL			string					`json:"absolute_url"`
 	Location	greenhouseLocation		`json:"location"`
 	UpdatedAt	string					`json:"updated_at"`
+	FirstPublished	string				`json:"first_published"`
 	Content		string					`json:"content"`
 	Department 	[]greenhouseDept  		`json:"departments"`	
 }
@@ -47,60 +49,87 @@ type greenhouseDept struct {
 	Name 	string `json:"name"`
 }
 
-func (g * GreenhouseScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
-	url := fmt.Sprintf("https://boards-api.greenhouse.io/v1/boards/%s/jobs", slug)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-	resp, err := g.client.Do(req)
+func (g *GreenhouseScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	jobs, _, err := g.FetchJobsIfChanged(ctx, slug, Validators{})
+	return jobs, err
+}
+
+// FetchJobsIfChanged implements ConditionalScraper.
+func (g *GreenhouseScraper) FetchJobsIfChanged(ctx context.Context, slug string, prev Validators) ([]RawJob, Validators, error) {
+	// content=true returns descriptions inline, so one request covers the board.
+	url := fmt.Sprintf("https://boards-api.greenhouse.io/v1/boards/%s/jobs?content=true", slug)
+	resp, next, err := getIfChanged(ctx, g.client, url, prev)
 	if err != nil {
-		return nil, fmt.Errorf("fetching greenhouse jobs: %w", err)
+		return nil, next, fmt.Errorf("fetching greenhouse jobs: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, next, &StatusError{Source: "greenhouse API", StatusCode: resp.StatusCode}
+	}
+
 	var jobList greenhouseJobList
 	if err := json.NewDecoder(resp.Body).Decode(&jobList); err != nil {
-		return nil, fmt.Errorf("decoding greenhouse response: %w", err)
+		return nil, next, fmt.Errorf("decoding greenhouse response: %w", err)
 	}
 
-	url = fmt.Sprintf("https://boards-api.greenhouse.io/v1/boards/%s/jobs?content=true", slug)
-	req, err = http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request for job content: %w", err)
-	}
-	resp, err = g.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("fetching greenhouse jobs with content: %w", err)
+	jobs := make([]RawJob, 0, len(jobList.JobList))
+	for _, pj := range jobList.JobList {
+		rj := greenhouseRawJob(pj.V)
+		rj.Raw = pj.Raw
+		jobs = append(jobs, rj)
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return jobs, next, nil
+}
 
-	if resp.StatusCode == http.StatusOK {
-		_ = json.NewDecoder(resp.Body).Decode(&jobList)
+func greenhouseRawJob(j greenhouseJob) RawJob {
+	// updated_at moves on every edit, so it is not the posting date.
+	var postedAt, updatedAt *time.Time
+	if t, err := time.Parse(time.RFC3339, j.FirstPublished); err == nil {
+		postedAt = &t
 	}
+	if t, err := time.Parse(time.RFC3339, j.UpdatedAt); err == nil {
+		updatedAt = &t
+	}
+	deptNames := make([]string, len(j.Department))
+	for i, d := range j.Department {
+		deptNames[i] = d.Name
+	}
+	return RawJob{
+		ExternalID: fmt.Sprintf("%d", j.ID),
+		Title:      j.Title,
+		Description: j.Content,
+		Location:    j.Location.Name,
+		Remote:      strings.Contains(strings.ToLower(j.Location.Name), "remote"),
+		Department:  strings.Join(deptNames, ", "),
+		URL:         j.URL,
+		PostedAt:    postedAt,
+		UpdatedAt:   updatedAt,
+	}
+}
 
-	jobs := make([]RawJob, 0, len(jobList.JobList))
-	for _, j := range jobList.JobList {
-		var postedAt *time.Time
-		if j.UpdatedAt != "" {
-			if t, err := time.Parse("2006-01-02T15:04:05-07:00", j.UpdatedAt); err == nil {
-				postedAt = &t
-			}
-		}
-		deptNames := make([]string, len(j.Department))
-		for i, d := range j.Department {
-			deptNames[i] = d.Name
-		}
-		jobs = append(jobs, RawJob{
-			ExternalID: fmt.Sprintf("%d", j.ID),
-			Title:      j.Title,
-			Description: j.Content,
-			Location:    j.Location.Name,
-			Remote:      strings.Contains(strings.ToLower(j.Location.Name), "remote"),
-			Department:  strings.Join(deptNames, ", "),
-			URL:         j.URL,
-			PostedAt:    postedAt,
-		})
+// DecodePayload implements PayloadDecoder.
+func (g *GreenhouseScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("greenhouse", raw, greenhouseRawJob)
+}
+
+// SlugFromURL recognises Greenhouse board URLs, including the embed iframe and
+// script (boards.greenhouse.io/embed/job_board?for=<slug>).
+func (g *GreenhouseScraper) SlugFromURL(u *url.URL) (string, bool) {
+	switch strings.ToLower(u.Hostname()) {
+	case "boards.greenhouse.io", "job-boards.greenhouse.io", "boards-api.greenhouse.io":
+	default:
+		return "", false
+	}
+	if slug := u.Query().Get("for"); slug != "" {
+		return slug, true
+	}
+	segs := pathSegments(u)
+	if len(segs) > 2 && segs[0] == "v1" && segs[1] == "boards" {
+		return segs[2], true
 	}
-	return jobs, nil
-}
\ No newline at end of file
+	if len(segs) > 0 && segs[0] != "embed" && segs[0] != "v1" {
+		return segs[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,221 @@
+package scraper
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/spf13/viper"
+)
+
+// htmlDefaultMaxPages caps pagination when a spec doesn't set max_pages.
+const htmlDefaultMaxPages = 10
+
+// HTMLSpec declares how to read a custom career page with CSS selectors.
+// Specs live in ~/.jobgo/config.yaml under html_scrapers.<company slug>:
+//
+//	html_scrapers:
+//	  acme:
+//	    url: https://acme.com/careers
+//	    item: "ul.openings > li"
+//	    title: "h3"
+//	    location: ".location"
+//	    link: "a"
+//	    next: "a.next-page"
+//
+// Title, location, department and link selectors are evaluated inside each
+// item. When description is set, every posting link is fetched and the
+// matching element on that page becomes the description.
+type HTMLSpec struct {
+	URL         string `mapstructure:"url"`
+	Item        string `mapstructure:"item"`
+	Title       string `mapstructure:"title"`
+	Location    string `mapstructure:"location"`
+	Department  string `mapstructure:"department"`
+	Link        string `mapstructure:"link"`
+	Description string `mapstructure:"description"`
+	Next        string `mapstructure:"next"`
+	MaxPages    int    `mapstructure:"max_pages"`
+}
+
+type HTMLScraper struct {
+	client   *http.Client
+	loadSpec func(slug string) (*HTMLSpec, error)
+}
+
+func NewHTMLScraper() *HTMLScraper {
+	return &HTMLScraper{
+		client:   newHTTPClient("html"),
+		loadSpec: htmlSpecFromConfig,
+	}
+}
+
+func (h *HTMLScraper) Name() string {
+	return "html"
+}
+
+func htmlSpecFromConfig(slug string) (*HTMLSpec, error) {
+	key := "html_scrapers." + slug
+	if !viper.IsSet(key) {
+		return nil, fmt.Errorf("no html scraper spec for %q: add one under %s in config.yaml", slug, key)
+	}
+	var spec HTMLSpec
+	if err := viper.UnmarshalKey(key, &spec); err != nil {
+		return nil, fmt.Errorf("reading html scraper spec %s: %w", key, err)
+	}
+	return &spec, nil
+}
+
+func (h *HTMLScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	spec, err := h.loadSpec(slug)
+	if err != nil {
+		return nil, err
+	}
+	if spec.URL == "" || spec.Item == "" {
+		return nil, fmt.Errorf("html scraper spec for %q needs url and item selectors", slug)
+	}
+
+	maxPages := spec.MaxPages
+	if maxPages <= 0 {
+		maxPages = htmlDefaultMaxPages
+	}
+
+	var jobs []RawJob
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+	pageURL := spec.URL
+	for page := 0; page < maxPages && pageURL != "" && !visited[pageURL]; page++ {
+		visited[pageURL] = true
+
+		doc, base, err := h.fetchDoc(ctx, pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.Find(spec.Item).Each(func(_ int, item *goquery.Selection) {
+			rj := htmlRawJob(spec, item, base)
+			if rj.Title == "" || seen[rj.ExternalID] {
+				return
+			}
+			seen[rj.ExternalID] = true
+			jobs = append(jobs, rj)
+		})
+
+		pageURL = ""
+		if spec.Next != "" {
+			if href, ok := doc.Find(spec.Next).First().Attr("href"); ok {
+				if next, err := base.Parse(href); err == nil {
+					pageURL = next.String()
+				}
+			}
+		}
+	}
+
+	// A next page beyond max_pages means postings were left unread.
+	var partial error
+	if pageURL != "" && !visited[pageURL] {
+		partial = &PartialError{Source: spec.URL, Reason: fmt.Sprintf("stopped at max_pages (%d)", maxPages)}
+	}
+
+	if spec.Description != "" {
+		err := fetchAll(len(jobs), detailWorkers, func(i int) error {
+			if !strings.HasPrefix(jobs[i].URL, "http") {
+				return nil
+			}
+			doc, _, err := h.fetchDoc(ctx, jobs[i].URL)
+			if err != nil {
+				return fmt.Errorf("fetching posting %s: %w", jobs[i].URL, err)
+			}
+			desc, _ := doc.Find(spec.Description).First().Html()
+			jobs[i].Description = strings.TrimSpace(desc)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jobs, partial
+}
+
+func (h *HTMLScraper) fetchDoc(ctx context.Context, pageURL string) (*goquery.Document, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching %s: %w", pageURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, &StatusError{Source: pageURL, StatusCode: resp.StatusCode}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", pageURL, err)
+	}
+	return doc, resp.Request.URL, nil
+}
+
+func htmlRawJob(spec *HTMLSpec, item *goquery.Selection, base *url.URL) RawJob {
+	title := selectText(item, spec.Title)
+	location := selectText(item, spec.Location)
+
+	link := item
+	if spec.Link != "" {
+		link = item.Find(spec.Link).First()
+	} else if !item.Is("a") {
+		link = item.Find("a[href]").First()
+	}
+	jobURL := ""
+	if href, ok := link.Attr("href"); ok {
+		if u, err := base.Parse(strings.TrimSpace(href)); err == nil {
+			u.Fragment = ""
+			jobURL = u.String()
+		}
+	}
+
+	externalID := jobURL
+	if externalID == "" {
+		sum := sha1.Sum([]byte(title + "|" + location))
+		externalID = hex.EncodeToString(sum[:8])
+		jobURL = base.String()
+	}
+
+	// The listing's markup is the closest thing this page has to a payload.
+	var raw json.RawMessage
+	if markup, err := goquery.OuterHtml(item); err == nil {
+		raw, _ = json.Marshal(markup)
+	}
+
+	return RawJob{
+		ExternalID: externalID,
+		Title:      title,
+		Location:   location,
+		Remote:     strings.Contains(strings.ToLower(location), "remote"),
+		Department: selectText(item, spec.Department),
+		URL:        jobURL,
+		Raw:        raw,
+	}
+}
+
+// selectText returns the whitespace-collapsed text of the first match of
+// selector within s, or of s itself when selector is empty.
+func selectText(s *goquery.Selection, selector string) string {
+	if selector != "" {
+		s = s.Find(selector).First()
+	}
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
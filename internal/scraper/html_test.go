@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTMLFetchJobs(t *testing.T) {
+	pages := map[string]string{
+		"/careers": `<html><body><ul class="openings">
+			<li><h3>Backend  Engineer</h3><span class="loc">Austin, TX</span><span class="team">Platform</span><a href="/careers/be#apply">Apply</a></li>
+			<li><h3>Data Engineer</h3><span class="loc">Remote - US</span><a href="careers/de">Apply</a></li>
+			<li><span class="loc">Nowhere</span></li>
+		</ul><a class="next" href="/careers?page=2">Next</a></body></html>`,
+		"/careers?page=2": `<html><body><ul class="openings">
+			<li><h3>Backend Engineer</h3><span class="loc">Austin, TX</span><a href="/careers/be">Apply</a></li>
+			<li><h3>Designer</h3><span class="loc">NYC</span><a href="/careers/design">Apply</a></li>
+		</ul><a class="next" href="/careers">Back</a></body></html>`,
+		"/careers/be":     `<html><body><div class="desc"><p>Go and PostgreSQL</p></div></body></html>`,
+		"/careers/de":     `<html><body><div class="desc"><p>Spark</p></div></body></html>`,
+		"/careers/design": `<html><body><div class="desc"><p>Figma</p></div></body></html>`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	h := &HTMLScraper{
+		client: srv.Client(),
+		loadSpec: func(slug string) (*HTMLSpec, error) {
+			return &HTMLSpec{
+				URL:         srv.URL + "/careers",
+				Item:        "ul.openings > li",
+				Title:       "h3",
+				Location:    ".loc",
+				Department:  ".team",
+				Link:        "a",
+				Description: ".desc",
+				Next:        "a.next",
+			}, nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	jobs, err := h.FetchJobs(ctx, "acme")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("got %d jobs, want 3: %+v", len(jobs), jobs)
+	}
+
+	be := jobs[0]
+	if be.Title != "Backend Engineer" || be.Location != "Austin, TX" || be.Department != "Platform" {
+		t.Errorf("unexpected first job: %+v", be)
+	}
+	if be.URL != srv.URL+"/careers/be" || be.ExternalID != be.URL {
+		t.Errorf("URL = %q, ExternalID = %q", be.URL, be.ExternalID)
+	}
+	if !strings.Contains(be.Description, "Go and PostgreSQL") {
+		t.Errorf("Description = %q", be.Description)
+	}
+
+	if de := jobs[1]; !de.Remote || de.URL != srv.URL+"/careers/de" {
+		t.Errorf("Data Engineer remote=%v url=%q", de.Remote, de.URL)
+	}
+	if jobs[2].Title != "Designer" {
+		t.Errorf("third job = %q, want Designer", jobs[2].Title)
+	}
+}
+
+func TestHTMLFetchJobs_MissingSpec(t *testing.T) {
+	h := NewHTMLScraper()
+	if _, err := h.FetchJobs(context.Background(), "no-such-company"); err == nil {
+		t.Error("expected error for missing spec")
+	}
+}
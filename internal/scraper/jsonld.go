@@ -0,0 +1,431 @@
+package scraper
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// jsonldMaxPages caps how many pages a single crawl may fetch.
+	jsonldMaxPages = 200
+	// jsonldMaxListingPages caps how many paginated listing pages are followed.
+	jsonldMaxListingPages = 20
+)
+
+// JSONLDScraper crawls a company's own career site and builds jobs from the
+// schema.org JobPosting JSON-LD embedded in its pages. It is handed the
+// company's career URL rather than an ATS slug.
+type JSONLDScraper struct {
+	client *http.Client
+}
+
+func NewJSONLDScraper() *JSONLDScraper {
+	return &JSONLDScraper{
+		client: newHTTPClient("jsonld"),
+	}
+}
+
+func (j *JSONLDScraper) Name() string {
+	return "jsonld"
+}
+
+func (j *JSONLDScraper) CrawlsCareerURL() {}
+
+// SlugFromCareerURL derives a slug for career page companies, which have no
+// ATS board name, from the host of their career URL.
+func SlugFromCareerURL(careerURL string) string {
+	u, err := url.Parse(careerURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+type crawlPage struct {
+	url     string
+	listing bool
+}
+
+// FetchJobs crawls breadth-first from the career URL. Listing pages (the
+// start page and its "next" pages) have their job-looking links followed;
+// posting pages are only read for JSON-LD. A page that fails to load, other
+// than the start page, or hitting a page limit makes the result partial.
+func (j *JSONLDScraper) FetchJobs(ctx context.Context, careerURL string) ([]RawJob, error) {
+	start, err := url.Parse(careerURL)
+	if err != nil || start.Host == "" {
+		return nil, fmt.Errorf("invalid career URL %q", careerURL)
+	}
+
+	queue := []crawlPage{{url: start.String(), listing: true}}
+	visited := map[string]bool{start.String(): true}
+	seenJobs := make(map[string]bool)
+	var jobs []RawJob
+	fetched, listings, failed := 0, 0, 0
+	truncated := false
+
+	for len(queue) > 0 && fetched < jsonldMaxPages {
+		page := queue[0]
+		queue = queue[1:]
+
+		doc, _, err := fetchHTML(ctx, j.client, page.url)
+		fetched++
+		if err != nil {
+			if page.url == start.String() {
+				return nil, err
+			}
+			failed++
+			continue
+		}
+
+		for _, rj := range jobPostingsFromDoc(doc, page.url) {
+			if seenJobs[rj.ExternalID] {
+				continue
+			}
+			seenJobs[rj.ExternalID] = true
+			jobs = append(jobs, rj)
+		}
+
+		if !page.listing {
+			continue
+		}
+		listings++
+
+		pageURL, _ := url.Parse(page.url)
+		for _, link := range extractLinks(doc, pageURL) {
+			if link.url.Host != start.Host || visited[link.url.String()] {
+				continue
+			}
+			switch {
+			case link.next && listings >= jsonldMaxListingPages:
+				truncated = true
+			case link.next:
+				visited[link.url.String()] = true
+				queue = append(queue, crawlPage{url: link.url.String(), listing: true})
+			case looksLikePostingPath(link.url.Path):
+				visited[link.url.String()] = true
+				queue = append(queue, crawlPage{url: link.url.String()})
+			}
+		}
+	}
+
+	switch {
+	case failed > 0:
+		return jobs, &PartialError{Source: careerURL, Reason: fmt.Sprintf("%d pages failed to load", failed)}
+	case len(queue) > 0:
+		return jobs, &PartialError{Source: careerURL, Reason: fmt.Sprintf("stopped at the %d-page limit", jsonldMaxPages)}
+	case truncated:
+		return jobs, &PartialError{Source: careerURL, Reason: fmt.Sprintf("stopped at the %d listing-page limit", jsonldMaxListingPages)}
+	}
+	return jobs, nil
+}
+
+type pageLink struct {
+	url  *url.URL
+	next bool
+}
+
+// extractLinks returns every resolvable <a href> on the page, flagging the
+// ones that point at the next listing page.
+func extractLinks(doc *html.Node, base *url.URL) []pageLink {
+	var links []pageLink
+	walkHTML(doc, func(n *html.Node) {
+		if n.Type != html.ElementNode || (n.Data != "a" && n.Data != "link") {
+			return
+		}
+		href := attr(n, "href")
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "mailto:") || strings.HasPrefix(href, "javascript:") {
+			return
+		}
+		u, err := base.Parse(href)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return
+		}
+		u.Fragment = ""
+
+		rel := strings.ToLower(attr(n, "rel"))
+		label := strings.ToLower(strings.TrimSpace(textContent(n) + " " + attr(n, "aria-label")))
+		next := strings.Contains(rel, "next") || label == "next" || strings.HasPrefix(label, "next ") || label == "›" || label == "»"
+		if n.Data == "link" && !next {
+			return
+		}
+		links = append(links, pageLink{url: u, next: next})
+	})
+	return links
+}
+
+var postingPathHints = []string{"job", "career", "position", "opening", "role", "vacanc", "requisition"}
+
+func looksLikePostingPath(path string) bool {
+	lower := strings.ToLower(path)
+	for _, hint := range postingPathHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// jobPostingsFromDoc extracts every schema.org JobPosting from the page's
+// <script type="application/ld+json"> blocks.
+func jobPostingsFromDoc(doc *html.Node, pageURL string) []RawJob {
+	var jobs []RawJob
+	walkHTML(doc, func(n *html.Node) {
+		if n.Type != html.ElementNode || n.Data != "script" || !strings.Contains(strings.ToLower(attr(n, "type")), "ld+json") {
+			return
+		}
+		var data any
+		if err := json.Unmarshal([]byte(textContent(n)), &data); err != nil {
+			return
+		}
+		for _, posting := range findJobPostings(data) {
+			rj := jsonldRawJob(posting, pageURL)
+			rj.Raw, _ = json.Marshal(posting)
+			jobs = append(jobs, rj)
+		}
+	})
+	return jobs
+}
+
+// findJobPostings walks arbitrary JSON-LD (single objects, arrays and @graph
+// containers) and returns the objects typed as JobPosting.
+func findJobPostings(v any) []map[string]any {
+	var found []map[string]any
+	switch x := v.(type) {
+	case []any:
+		for _, item := range x {
+			found = append(found, findJobPostings(item)...)
+		}
+	case map[string]any:
+		if hasType(x, "JobPosting") {
+			return []map[string]any{x}
+		}
+		if graph, ok := x["@graph"]; ok {
+			found = append(found, findJobPostings(graph)...)
+		}
+		if list, ok := x["itemListElement"]; ok {
+			found = append(found, findJobPostings(list)...)
+		}
+		if item, ok := x["item"]; ok {
+			found = append(found, findJobPostings(item)...)
+		}
+	}
+	return found
+}
+
+func hasType(obj map[string]any, want string) bool {
+	switch t := obj["@type"].(type) {
+	case string:
+		return strings.EqualFold(t, want)
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && strings.EqualFold(s, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonldRawJob(p map[string]any, pageURL string) RawJob {
+	jobURL := ldString(p["url"])
+	if jobURL == "" {
+		jobURL = pageURL
+	}
+
+	var locations []string
+	for _, place := range ldList(p["jobLocation"]) {
+		obj, ok := place.(map[string]any)
+		if !ok {
+			if s := ldString(place); s != "" {
+				locations = append(locations, s)
+			}
+			continue
+		}
+		addr, ok := obj["address"].(map[string]any)
+		if !ok {
+			if s := ldString(obj["address"]); s != "" {
+				locations = append(locations, s)
+			}
+			continue
+		}
+		locations = append(locations, joinNonEmpty(", ",
+			ldString(addr["addressLocality"]),
+			ldString(addr["addressRegion"]),
+			ldString(addr["addressCountry"]),
+		))
+	}
+
+	remote := strings.EqualFold(ldString(p["jobLocationType"]), "TELECOMMUTE")
+	if remote {
+		remoteLabel := "Remote"
+		if req := ldString(p["applicantLocationRequirements"]); req != "" {
+			remoteLabel += " - " + req
+		}
+		locations = append(locations, remoteLabel)
+	}
+	location := strings.Join(locations, "; ")
+
+	var postedAt *time.Time
+	if t, ok := parseLDDate(ldString(p["datePosted"])); ok {
+		postedAt = &t
+	}
+
+	var employmentTypes []string
+	for _, et := range ldList(p["employmentType"]) {
+		if s := ldString(et); s != "" {
+			employmentTypes = append(employmentTypes, s)
+		}
+	}
+
+	externalID := ldString(p["identifier"])
+	if externalID == "" {
+		externalID = jobURL
+	}
+	if externalID == pageURL && ldString(p["url"]) == "" {
+		// Several postings embedded on one page would collide on the page URL.
+		sum := sha1.Sum([]byte(ldString(p["title"]) + "|" + location))
+		externalID = pageURL + "#" + hex.EncodeToString(sum[:6])
+	}
+
+	return RawJob{
+		ExternalID:     externalID,
+		Title:          strings.TrimSpace(ldString(p["title"])),
+		Description:    ldString(p["description"]),
+		Location:       location,
+		Remote:         remote || strings.Contains(strings.ToLower(location), "remote"),
+		Department:     ldString(p["occupationalCategory"]),
+		URL:            jobURL,
+		PostedAt:       postedAt,
+		EmploymentType: strings.Join(employmentTypes, ", "),
+		Compensation:   ldCompensation(p["baseSalary"]),
+	}
+}
+
+// DecodePayload implements PayloadDecoder.
+func (j *JSONLDScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("jsonld", raw, func(p map[string]any) RawJob {
+		return jsonldRawJob(p, "")
+	})
+}
+
+// ldCompensation renders a schema.org MonetaryAmount as a short summary,
+// e.g. "USD 120000–150000 per year".
+func ldCompensation(v any) string {
+	amount, ok := v.(map[string]any)
+	if !ok {
+		return ldString(v)
+	}
+	currency := ldString(amount["currency"])
+	value, ok := amount["value"].(map[string]any)
+	if !ok {
+		if s := ldString(amount["value"]); s != "" {
+			return strings.TrimSpace(currency + " " + s)
+		}
+		return ""
+	}
+
+	min, max := ldString(value["minValue"]), ldString(value["maxValue"])
+	if min == "" && max == "" {
+		min = ldString(value["value"])
+	}
+	var amountText string
+	switch {
+	case min != "" && max != "" && min != max:
+		amountText = min + "–" + max
+	case min != "":
+		amountText = min
+	default:
+		amountText = max
+	}
+	if amountText == "" {
+		return ""
+	}
+
+	summary := strings.TrimSpace(currency + " " + amountText)
+	if unit := ldString(value["unitText"]); unit != "" {
+		summary += " per " + strings.ToLower(unit)
+	}
+	return summary
+}
+
+// ldString flattens the JSON-LD value shapes that stand in for text:
+// plain strings, numbers, {"name": ...}/{"value": ...} objects and lists.
+func ldString(v any) string {
+	switch x := v.(type) {
+	case string:
+		return html.UnescapeString(strings.TrimSpace(x))
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case map[string]any:
+		for _, key := range []string{"value", "@value", "name", "@id"} {
+			if s := ldString(x[key]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		var parts []string
+		for _, item := range x {
+			if s := ldString(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+func ldList(v any) []any {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return x
+	default:
+		return []any{x}
+	}
+}
+
+func parseLDDate(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func walkHTML(n *html.Node, fn func(*html.Node)) {
+	fn(n)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkHTML(c, fn)
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	walkHTML(n, func(c *html.Node) {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+		}
+	})
+	return sb.String()
+}
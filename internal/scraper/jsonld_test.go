@@ -0,0 +1,146 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const listingPage1 = `<html><body>
+<ul>
+  <li><a href="/careers/backend-engineer">Backend Engineer</a></li>
+  <li><a href="/careers/data-engineer">Data Engineer</a></li>
+  <li><a href="/about">About us</a></li>
+</ul>
+<a rel="next" href="/careers?page=2">Next</a>
+</body></html>`
+
+const listingPage2 = `<html><body>
+<a href="/careers/backend-engineer">Backend Engineer</a>
+<a href="/careers/designer">Product Designer</a>
+</body></html>`
+
+func postingPage(ld string) string {
+	return fmt.Sprintf(`<html><head><script type="application/ld+json">%s</script></head><body></body></html>`, ld)
+}
+
+func TestJSONLDFetchJobs(t *testing.T) {
+	pages := map[string]string{
+		"/careers":        listingPage1,
+		"/careers?page=2": listingPage2,
+		"/careers/backend-engineer": postingPage(`{
+			"@context": "https://schema.org",
+			"@type": "JobPosting",
+			"title": "Backend Engineer",
+			"description": "<p>Requirements: Go &amp; PostgreSQL</p>",
+			"identifier": {"@type": "PropertyValue", "name": "Acme", "value": "BE-1"},
+			"datePosted": "2024-03-01",
+			"employmentType": ["FULL_TIME"],
+			"jobLocation": {"@type": "Place", "address": {"@type": "PostalAddress", "addressLocality": "Austin", "addressRegion": "TX", "addressCountry": "US"}},
+			"baseSalary": {"@type": "MonetaryAmount", "currency": "USD", "value": {"@type": "QuantitativeValue", "minValue": 120000, "maxValue": 150000, "unitText": "YEAR"}}
+		}`),
+		"/careers/data-engineer": postingPage(`{"@context": "https://schema.org", "@graph": [
+			{"@type": "Organization", "name": "Acme"},
+			{"@type": "JobPosting", "title": "Data Engineer", "jobLocationType": "TELECOMMUTE", "applicantLocationRequirements": {"@type": "Country", "name": "USA"}}
+		]}`),
+		"/careers/designer": postingPage(`[{"@type": "JobPosting", "title": "Product Designer", "url": "https://example.com/jobs/42"}]`),
+		"/about":            postingPage(`{"@type": "JobPosting", "title": "Should not be crawled"}`),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	jobs, err := NewJSONLDScraper().FetchJobs(ctx, srv.URL+"/careers")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+
+	byTitle := make(map[string]RawJob)
+	for _, j := range jobs {
+		byTitle[j.Title] = j
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("got %d jobs, want 3: %+v", len(jobs), jobs)
+	}
+
+	be := byTitle["Backend Engineer"]
+	if be.ExternalID != "BE-1" {
+		t.Errorf("ExternalID = %q, want BE-1", be.ExternalID)
+	}
+	if be.Location != "Austin, TX, US" {
+		t.Errorf("Location = %q, want Austin, TX, US", be.Location)
+	}
+	if be.Description != "<p>Requirements: Go & PostgreSQL</p>" {
+		t.Errorf("Description = %q", be.Description)
+	}
+	if be.EmploymentType != "FULL_TIME" {
+		t.Errorf("EmploymentType = %q, want FULL_TIME", be.EmploymentType)
+	}
+	if be.Compensation != "USD 120000–150000 per year" {
+		t.Errorf("Compensation = %q", be.Compensation)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(be.Raw, &raw); err != nil || raw["@type"] != "JobPosting" {
+		t.Errorf("Raw = %s, want the JobPosting object", be.Raw)
+	}
+	if be.PostedAt == nil || be.PostedAt.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("PostedAt = %v, want 2024-03-01", be.PostedAt)
+	}
+	if be.URL != srv.URL+"/careers/backend-engineer" {
+		t.Errorf("URL = %q", be.URL)
+	}
+
+	de := byTitle["Data Engineer"]
+	if !de.Remote || de.Location != "Remote - USA" {
+		t.Errorf("Data Engineer remote=%v location=%q, want remote in USA", de.Remote, de.Location)
+	}
+
+	if pd := byTitle["Product Designer"]; pd.URL != "https://example.com/jobs/42" || pd.ExternalID != pd.URL {
+		t.Errorf("Product Designer url=%q id=%q", pd.URL, pd.ExternalID)
+	}
+}
+
+func TestJSONLDFetchJobs_BadStartPage(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if _, err := NewJSONLDScraper().FetchJobs(context.Background(), srv.URL+"/careers"); err == nil {
+		t.Error("expected error for missing career page")
+	}
+}
+
+func TestJSONLDFetchJobs_FailedPostingIsPartial(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/careers":
+			_, _ = fmt.Fprint(w, `<a href="/careers/backend-engineer">Backend</a><a href="/careers/data-engineer">Data</a>`)
+		case "/careers/backend-engineer":
+			_, _ = fmt.Fprint(w, postingPage(`{"@type": "JobPosting", "title": "Backend Engineer"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	jobs, err := NewJSONLDScraper().FetchJobs(context.Background(), srv.URL+"/careers")
+	if !IsPartial(err) {
+		t.Fatalf("err = %v, want a partial crawl", err)
+	}
+	if len(jobs) != 1 || jobs[0].Title != "Backend Engineer" {
+		t.Errorf("jobs = %+v, want the posting that loaded", jobs)
+	}
+}
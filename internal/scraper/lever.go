@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
+
+	"github.com/Trungsherlock/jobgo/internal/salary"
 )
 
 type LeverScraper struct {
@@ -15,7 +19,7 @@ type LeverScraper struct {
 
 func NewLeverScraper() *LeverScraper {
 	return &LeverScraper{
-		client: &http.Client{Timeout: 30 * time.Second},
+		client: newHTTPClient("lever"),
 	}
 }
 
@@ -33,6 +37,14 @@ type leverPosting struct {
 	DescriptionPlain	string			`json:"descriptionPlain"`
 	Lists 				[]leverList		`json:"lists"`
 	CreatedAt			int64			`json:"createdAt"`
+	SalaryRange			*leverSalary	`json:"salaryRange"`
+}
+
+type leverSalary struct {
+	Currency	string	`json:"currency"`
+	Interval	string	`json:"interval"`
+	Min			float64	`json:"min"`
+	Max			float64	`json:"max"`
 }
 
 type leverCategories struct {
@@ -49,53 +61,104 @@ type leverList struct {
 }
 
 func (l *LeverScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
-	url := fmt.Sprintf("https://api.lever.co/v0/postings/%s?mode=json", slug)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
+	jobs, _, err := l.FetchJobsIfChanged(ctx, slug, Validators{})
+	return jobs, err
+}
 
-	resp, err := l.client.Do(req)
+// FetchJobsIfChanged implements ConditionalScraper.
+func (l *LeverScraper) FetchJobsIfChanged(ctx context.Context, slug string, prev Validators) ([]RawJob, Validators, error) {
+	url := fmt.Sprintf("https://api.lever.co/v0/postings/%s?mode=json", slug)
+	resp, next, err := getIfChanged(ctx, l.client, url, prev)
 	if err != nil {
-		return nil, fmt.Errorf("fetching lever postings: %w", err)
+		return nil, next, fmt.Errorf("fetching lever postings: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("lever API returned status %d", resp.StatusCode)
+		return nil, next, &StatusError{Source: "lever API", StatusCode: resp.StatusCode}
 	}
 
-	var postings []leverPosting
+	var postings []payload[leverPosting]
 	if err := json.NewDecoder(resp.Body).Decode(&postings); err != nil {
-		return nil, fmt.Errorf("decoding lever response: %w", err)
+		return nil, next, fmt.Errorf("decoding lever response: %w", err)
 	}
 
 	jobs := make([]RawJob, 0, len(postings))
-	for _, p := range postings {
-		description := p.DescriptionPlain
-		for _, list := range p.Lists {
-			description += "\n\n" + list.Text + "\n" + list.Content
-		}
+	for _, pp := range postings {
+		rj := leverRawJob(pp.V)
+		rj.Raw = pp.Raw
+		jobs = append(jobs, rj)
+	}
+
+	return jobs, next, nil
+}
 
-		var postedAt *time.Time
-		if p.CreatedAt > 0 {
-			t := time.UnixMilli(p.CreatedAt)
-			postedAt = &t
+// leverInterval maps Lever's salary intervals ("per-year-salary",
+// "per-hour-wage", ...) to a salary period.
+func leverInterval(interval string) string {
+	for _, p := range []string{salary.Hour, salary.Day, salary.Week, salary.Month} {
+		if strings.Contains(interval, p) {
+			return p
 		}
+	}
+	return salary.Year
+}
 
-		remote := strings.Contains(strings.ToLower(p.Categories.Location), "remote")
-
-		jobs = append(jobs, RawJob{
-			ExternalID: p.ID,
-			Title:      p.Text,
-			Description: description,
-			Location:    p.Categories.Location,
-			Remote:      remote,
-			Department:  p.Categories.Department,
-			URL:         p.HostedURL,
-			PostedAt:    postedAt,
-		})
+func leverRawJob(p leverPosting) RawJob {
+	// Keep the markup so the lists' headings and bullets survive
+	// description normalization.
+	description := p.Description
+	if description == "" {
+		description = "<p>" + strings.ReplaceAll(html.EscapeString(p.DescriptionPlain), "\n", "<br>") + "</p>"
+	}
+	for _, list := range p.Lists {
+		description += "<h3>" + html.EscapeString(list.Text) + "</h3><ul>" + list.Content + "</ul>"
 	}
 
-	return jobs, nil
-}
\ No newline at end of file
+	var postedAt *time.Time
+	if p.CreatedAt > 0 {
+		t := time.UnixMilli(p.CreatedAt)
+		postedAt = &t
+	}
+
+	remote := strings.Contains(strings.ToLower(p.Categories.Location), "remote")
+
+	var compensation string
+	if sr := p.SalaryRange; sr != nil {
+		compensation = salary.FormatSummary(sr.Min, sr.Max, sr.Currency, leverInterval(sr.Interval))
+	}
+
+	return RawJob{
+		ExternalID: p.ID,
+		Title:      p.Text,
+		Description: description,
+		Location:    p.Categories.Location,
+		Remote:      remote,
+		Department:  p.Categories.Department,
+		URL:         p.HostedURL,
+		PostedAt:    postedAt,
+		Compensation: compensation,
+		EmploymentType: p.Categories.Commitment,
+	}
+}
+
+// DecodePayload implements PayloadDecoder.
+func (l *LeverScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("lever", raw, leverRawJob)
+}
+
+// SlugFromURL recognises jobs.lever.co/<slug> board and posting URLs.
+func (l *LeverScraper) SlugFromURL(u *url.URL) (string, bool) {
+	segs := pathSegments(u)
+	switch strings.ToLower(u.Hostname()) {
+	case "jobs.lever.co":
+		if len(segs) > 0 {
+			return segs[0], true
+		}
+	case "api.lever.co":
+		if len(segs) > 2 && segs[0] == "v0" && segs[1] == "postings" {
+			return segs[2], true
+		}
+	}
+	return "", false
+}
@@ -39,4 +39,27 @@ func TestLeverFetchJobs(t *testing.T) {
 	}
 
 	t.Logf("Sample job: %s - %s (%s)", j.Title, j.Location, j.URL)
-}
\ No newline at end of file
+}
+func TestLeverDecodePayload(t *testing.T) {
+	raw := []byte(`{"id":"abc","text":"Backend Engineer","hostedURL":"https://jobs.lever.co/x/abc",
+		"categories":{"location":"Remote - US","commitment":"Full-time"},
+		"salaryRange":{"currency":"USD","interval":"per-year-salary","min":120000,"max":150000}}`)
+
+	j, err := NewLeverScraper().DecodePayload(raw)
+	if err != nil {
+		t.Fatalf("DecodePayload error: %v", err)
+	}
+	if j.ExternalID != "abc" || j.Location != "Remote - US" || !j.Remote || j.EmploymentType != "Full-time" {
+		t.Errorf("decoded %+v", j)
+	}
+	if j.Compensation == "" {
+		t.Error("Expected Compensation from salaryRange")
+	}
+	if string(j.Raw) != string(raw) {
+		t.Error("Expected Raw to be the stored payload")
+	}
+
+	if _, err := NewLeverScraper().DecodePayload([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for a malformed payload")
+	}
+}
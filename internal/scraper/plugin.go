@@ -0,0 +1,258 @@
+package scraper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// PluginPrefix is the executable name prefix for external scrapers:
+// jobgo-scraper-<platform>.
+const PluginPrefix = "jobgo-scraper-"
+
+const (
+	pluginDefaultTimeout = 2 * time.Minute
+	// pluginMaxLine bounds one JSON line (a single posting with description).
+	pluginMaxLine = 4 << 20
+	// pluginStderrTail is how much of a failing plugin's stderr is kept.
+	pluginStderrTail = 2 << 10
+)
+
+// Exit codes a plugin can use to report a specific failure. Any other
+// non-zero code is a generic failure.
+const (
+	PluginExitNotFound    = 10
+	PluginExitRateLimited = 11
+)
+
+// PluginScraper runs an external executable for a platform jobgo does not
+// ship. The executable receives the slug as its only argument and writes one
+// JSON object per job to stdout, using the pluginJob field names.
+type PluginScraper struct {
+	platform string
+	path     string
+	timeout  time.Duration
+}
+
+func NewPluginScraper(platform, path string, timeout time.Duration) *PluginScraper {
+	if timeout <= 0 {
+		timeout = pluginDefaultTimeout
+	}
+	return &PluginScraper{platform: platform, path: path, timeout: timeout}
+}
+
+func (p *PluginScraper) Name() string {
+	return p.platform
+}
+
+// Path returns the executable the plugin runs.
+func (p *PluginScraper) Path() string {
+	return p.path
+}
+
+// pluginJob is the line format plugins emit.
+type pluginJob struct {
+	ExternalID     string `json:"external_id"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Location       string `json:"location"`
+	Remote         bool   `json:"remote"`
+	Department     string `json:"department"`
+	URL            string `json:"url"`
+	PostedAt       string `json:"posted_at"`
+	EmploymentType string `json:"employment_type"`
+	Compensation   string `json:"compensation"`
+}
+
+// PluginError reports a plugin that exited non-zero. The not-found and
+// rate-limited exit codes unwrap to a StatusError so they classify like the
+// equivalent HTTP responses.
+type PluginError struct {
+	Platform string
+	ExitCode int
+	Stderr   string
+}
+
+func (e *PluginError) Error() string {
+	msg := fmt.Sprintf("plugin %s%s exited with code %d", PluginPrefix, e.Platform, e.ExitCode)
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+func (e *PluginError) Unwrap() error {
+	source := "plugin " + PluginPrefix + e.Platform
+	switch e.ExitCode {
+	case PluginExitNotFound:
+		return &StatusError{Source: source, StatusCode: http.StatusNotFound}
+	case PluginExitRateLimited:
+		return &StatusError{Source: source, StatusCode: http.StatusTooManyRequests}
+	}
+	return nil
+}
+
+func (p *PluginScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.path, slug)
+	var stderr tailBuffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating plugin pipe: %w", err)
+	}
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting plugin %s: %w", p.path, err)
+	}
+	// Children of the plugin can hold stdout open after it is killed; close
+	// our end on timeout so the read loop below returns.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = stdout.Close()
+		case <-done:
+		}
+	}()
+
+	var jobs []RawJob
+	var parseErr error
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64<<10), pluginMaxLine)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 || parseErr != nil {
+			continue
+		}
+		var pj pluginJob
+		if err := json.Unmarshal(text, &pj); err != nil {
+			parseErr = fmt.Errorf("plugin %s: decoding line %d: %w", p.platform, line, err)
+			continue
+		}
+		rj := pj.rawJob()
+		rj.Raw = append(json.RawMessage(nil), text...)
+		jobs = append(jobs, rj)
+	}
+	if err := scanner.Err(); err != nil && parseErr == nil {
+		parseErr = fmt.Errorf("plugin %s: reading output: %w", p.platform, err)
+	}
+
+	waitErr := cmd.Wait()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("plugin %s timed out after %s: %w", p.platform, p.timeout, context.DeadlineExceeded)
+	}
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(waitErr, &exitErr) {
+			return nil, &PluginError{Platform: p.platform, ExitCode: exitErr.ExitCode(), Stderr: stderr.String()}
+		}
+		return nil, fmt.Errorf("running plugin %s: %w", p.platform, waitErr)
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return jobs, nil
+}
+
+func (pj pluginJob) rawJob() RawJob {
+	var postedAt *time.Time
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, pj.PostedAt); err == nil {
+			postedAt = &t
+			break
+		}
+	}
+	return RawJob{
+		ExternalID:     pj.ExternalID,
+		Title:          pj.Title,
+		Description:    pj.Description,
+		Location:       pj.Location,
+		Remote:         pj.Remote,
+		Department:     pj.Department,
+		URL:            pj.URL,
+		PostedAt:       postedAt,
+		EmploymentType: pj.EmploymentType,
+		Compensation:   pj.Compensation,
+	}
+}
+
+// DecodePayload implements PayloadDecoder.
+func (p *PluginScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload(p.platform, raw, pluginJob.rawJob)
+}
+
+// PluginDirs returns the directories searched for plugins, in priority order:
+// plugins.dir (default ~/.jobgo/plugins) and then $PATH.
+func PluginDirs() []string {
+	var dirs []string
+	if dir := viper.GetString("plugins.dir"); dir != "" {
+		dirs = append(dirs, dir)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".jobgo", "plugins"))
+	}
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+// DiscoverPlugins finds jobgo-scraper-<platform> executables in dirs. When
+// the same platform appears twice, the earlier directory wins.
+func DiscoverPlugins(dirs []string, timeout time.Duration) []*PluginScraper {
+	seen := make(map[string]bool)
+	var plugins []*PluginScraper
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, ".exe")
+			}
+			platform, ok := strings.CutPrefix(name, PluginPrefix)
+			if !ok || platform == "" || seen[platform] {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() || (runtime.GOOS != "windows" && info.Mode()&0o111 == 0) {
+				continue
+			}
+			seen[platform] = true
+			plugins = append(plugins, NewPluginScraper(platform, path, timeout))
+		}
+	}
+	return plugins
+}
+
+// tailBuffer keeps the last pluginStderrTail bytes written to it.
+type tailBuffer struct {
+	buf []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > pluginStderrTail {
+		t.buf = t.buf[len(t.buf)-pluginStderrTail:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return strings.TrimSpace(string(t.buf))
+}
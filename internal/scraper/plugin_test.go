@@ -0,0 +1,114 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePlugin(t *testing.T, dir, platform, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, PluginPrefix+platform)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatalf("writing plugin: %v", err)
+	}
+	return path
+}
+
+func TestPluginScraper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	dir := t.TempDir()
+
+	writePlugin(t, dir, "nichejobs", `
+[ "$1" = "acme" ] || { echo "unknown board $1" >&2; exit 10; }
+echo '{"external_id":"1","title":"Backend Engineer","location":"Remote","remote":true,"url":"https://niche.example/1","posted_at":"2024-03-01"}'
+echo
+echo '{"external_id":"2","title":"Data Engineer","posted_at":"2024-03-02T10:00:00Z","employment_type":"Contract"}'
+`)
+	writePlugin(t, dir, "throttled", `echo "slow down" >&2; exit 11`)
+	writePlugin(t, dir, "broken", `echo "boom" >&2; exit 3`)
+	writePlugin(t, dir, "garbage", `echo 'not json'`)
+	writePlugin(t, dir, "slow", `sleep 5`)
+	_ = os.WriteFile(filepath.Join(dir, PluginPrefix+"notexec"), []byte("#!/bin/sh\n"), 0o644)
+
+	plugins := make(map[string]*PluginScraper)
+	for _, p := range DiscoverPlugins([]string{dir}, 0) {
+		plugins[p.Name()] = p
+	}
+	if len(plugins) != 5 {
+		t.Fatalf("discovered %d plugins, want 5", len(plugins))
+	}
+	if _, ok := plugins["notexec"]; ok {
+		t.Error("non-executable file should not be discovered")
+	}
+
+	ctx := context.Background()
+
+	jobs, err := plugins["nichejobs"].FetchJobs(ctx, "acme")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+	if jobs[0].Title != "Backend Engineer" || !jobs[0].Remote || jobs[0].PostedAt == nil {
+		t.Errorf("unexpected first job: %+v", jobs[0])
+	}
+	if jobs[1].EmploymentType != "Contract" || jobs[1].PostedAt == nil {
+		t.Errorf("unexpected second job: %+v", jobs[1])
+	}
+	if want := `{"external_id":"2","title":"Data Engineer","posted_at":"2024-03-02T10:00:00Z","employment_type":"Contract"}`; string(jobs[1].Raw) != want {
+		t.Errorf("Raw = %s, want the plugin's line", jobs[1].Raw)
+	}
+
+	var statusErr *StatusError
+	_, err = plugins["nichejobs"].FetchJobs(ctx, "nope")
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("exit 10 should map to not found, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "unknown board nope") {
+		t.Errorf("error should carry stderr, got %v", err)
+	}
+
+	_, err = plugins["throttled"].FetchJobs(ctx, "acme")
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("exit 11 should map to rate limited, got %v", err)
+	}
+
+	var pluginErr *PluginError
+	_, err = plugins["broken"].FetchJobs(ctx, "acme")
+	if !errors.As(err, &pluginErr) || pluginErr.ExitCode != 3 || errors.As(err, &statusErr) {
+		t.Errorf("exit 3 should be a plain PluginError, got %v", err)
+	}
+
+	if _, err := plugins["garbage"].FetchJobs(ctx, "acme"); err == nil {
+		t.Error("expected error for malformed output")
+	}
+
+	slow := NewPluginScraper("slow", plugins["slow"].Path(), 100*time.Millisecond)
+	if _, err := slow.FetchJobs(ctx, "acme"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected timeout, got %v", err)
+	}
+}
+
+func TestDiscoverPlugins_FirstDirWins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	want := writePlugin(t, first, "niche", "exit 0")
+	writePlugin(t, second, "niche", "exit 0")
+
+	plugins := DiscoverPlugins([]string{first, second}, 0)
+	if len(plugins) != 1 || plugins[0].Path() != want {
+		t.Errorf("got %+v, want single plugin at %s", plugins, want)
+	}
+}
@@ -0,0 +1,123 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type RecruiteeScraper struct {
+	client *http.Client
+}
+
+func NewRecruiteeScraper() *RecruiteeScraper {
+	return &RecruiteeScraper{
+		client: newHTTPClient("recruitee"),
+	}
+}
+
+func (r *RecruiteeScraper) Name() string {
+	return "recruitee"
+}
+
+// Recruitee careers site API response structure
+type recruiteeOfferList struct {
+	Offers []payload[recruiteeOffer] `json:"offers"`
+}
+
+type recruiteeOffer struct {
+	ID                 int64  `json:"id"`
+	Title              string `json:"title"`
+	Description        string `json:"description"`
+	Requirements       string `json:"requirements"`
+	Location           string `json:"location"`
+	Remote             bool   `json:"remote"`
+	Department         string `json:"department"`
+	CareersURL         string `json:"careers_url"`
+	PublishedAt        string `json:"published_at"`
+	CreatedAt          string `json:"created_at"`
+	EmploymentTypeCode string `json:"employment_type_code"`
+	Status             string `json:"status"`
+}
+
+func (r *RecruiteeScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	jobs, _, err := r.FetchJobsIfChanged(ctx, slug, Validators{})
+	return jobs, err
+}
+
+// FetchJobsIfChanged implements ConditionalScraper.
+func (r *RecruiteeScraper) FetchJobsIfChanged(ctx context.Context, slug string, prev Validators) ([]RawJob, Validators, error) {
+	url := fmt.Sprintf("https://%s.recruitee.com/api/offers/", slug)
+	resp, next, err := getIfChanged(ctx, r.client, url, prev)
+	if err != nil {
+		return nil, next, fmt.Errorf("fetching recruitee offers: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, next, &StatusError{Source: "recruitee API", StatusCode: resp.StatusCode}
+	}
+
+	var list recruiteeOfferList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, next, fmt.Errorf("decoding recruitee response: %w", err)
+	}
+
+	jobs := make([]RawJob, 0, len(list.Offers))
+	for _, po := range list.Offers {
+		if po.V.Status != "" && po.V.Status != "published" {
+			continue
+		}
+		rj := recruiteeRawJob(po.V)
+		rj.Raw = po.Raw
+		jobs = append(jobs, rj)
+	}
+
+	return jobs, next, nil
+}
+
+func recruiteeRawJob(o recruiteeOffer) RawJob {
+	var postedAt *time.Time
+	for _, d := range []string{o.PublishedAt, o.CreatedAt} {
+		if t, err := time.Parse("2006-01-02 15:04:05 MST", d); err == nil {
+			postedAt = &t
+			break
+		}
+	}
+
+	description := o.Description
+	if o.Requirements != "" {
+		description += "<h3>Requirements</h3>" + o.Requirements
+	}
+
+	return RawJob{
+		ExternalID:     fmt.Sprintf("%d", o.ID),
+		Title:          o.Title,
+		Description:    description,
+		Location:       o.Location,
+		Remote:         o.Remote || strings.Contains(strings.ToLower(o.Location), "remote"),
+		Department:     o.Department,
+		URL:            o.CareersURL,
+		PostedAt:       postedAt,
+		EmploymentType: o.EmploymentTypeCode,
+	}
+}
+
+// DecodePayload implements PayloadDecoder.
+func (r *RecruiteeScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("recruitee", raw, recruiteeRawJob)
+}
+
+// SlugFromURL recognises <slug>.recruitee.com.
+func (r *RecruiteeScraper) SlugFromURL(u *url.URL) (string, bool) {
+	host := strings.ToLower(u.Hostname())
+	slug, ok := strings.CutSuffix(host, ".recruitee.com")
+	if !ok || slug == "" || strings.Contains(slug, ".") {
+		return "", false
+	}
+	return slug, true
+}
@@ -0,0 +1,96 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRecruiteeFetchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	scraper := NewRecruiteeScraper()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Recruitee hosts its own careers page on Recruitee
+	jobs, err := scraper.FetchJobs(ctx, "recruitee")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+
+	t.Logf("Found %d jobs from Recruitee/recruitee", len(jobs))
+
+	if len(jobs) == 0 {
+		t.Log("Warning: no jobs returned")
+		return
+	}
+
+	j := jobs[0]
+	if j.ExternalID == "" {
+		t.Error("ExternalID is empty")
+	}
+	if j.Title == "" {
+		t.Error("Title is empty")
+	}
+	if j.URL == "" {
+		t.Error("URL is empty")
+	}
+
+	t.Logf("Sample job: %s — %s (%s) [%s] posted %v", j.Title, j.Location, j.URL, j.Department, j.PostedAt)
+}
+
+func TestRecruiteeDecodePayload(t *testing.T) {
+	raw := []byte(`{"id":1042,"title":"Backend Engineer","description":"<p>Go</p>","requirements":"<ul><li>SQL</li></ul>",
+		"location":"Amsterdam, Netherlands","department":"Engineering","careers_url":"https://acme.recruitee.com/o/backend-engineer",
+		"published_at":"2026-03-01 09:30:00 UTC","created_at":"2026-02-20 08:00:00 UTC","employment_type_code":"fulltime_permanent","status":"published"}`)
+
+	j, err := NewRecruiteeScraper().DecodePayload(raw)
+	if err != nil {
+		t.Fatalf("DecodePayload error: %v", err)
+	}
+	if j.ExternalID != "1042" || j.Remote || j.EmploymentType != "fulltime_permanent" || j.Department != "Engineering" {
+		t.Errorf("decoded %+v", j)
+	}
+	if j.Description != "<p>Go</p><h3>Requirements</h3><ul><li>SQL</li></ul>" {
+		t.Errorf("Description = %q", j.Description)
+	}
+	if j.PostedAt == nil || !j.PostedAt.Equal(time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("PostedAt = %v, want published_at", j.PostedAt)
+	}
+	if string(j.Raw) != string(raw) {
+		t.Error("Expected Raw to be the stored payload")
+	}
+
+	if _, err := NewRecruiteeScraper().DecodePayload([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for a malformed payload")
+	}
+}
+
+func TestRecruiteeFetchJobsSkipsUnpublished(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offers":[
+			{"id":1,"title":"Backend Engineer","status":"published"},
+			{"id":2,"title":"Old Role","status":"closed"},
+			{"id":3,"title":"Draft Role","status":"draft"},
+			{"id":4,"title":"Data Engineer"}]}`))
+	}))
+	defer srv.Close()
+
+	r := &RecruiteeScraper{client: serverClient(srv)}
+	jobs, err := r.FetchJobs(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	var ids []string
+	for _, j := range jobs {
+		ids = append(ids, j.ExternalID)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "4" {
+		t.Errorf("got offers %v, want the published one and the one without a status", ids)
+	}
+}
@@ -1,6 +1,11 @@
 package scraper
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spf13/viper"
+)
 
 type Registry struct {
 	scrapers map[string]Scraper
@@ -13,10 +18,39 @@ func NewRegistry() *Registry {
 
 	r.Register(NewLeverScraper())
 	r.Register(NewGreenhouseScraper())
+	r.Register(NewAshbyScraper())
+	r.Register(NewWorkdayScraper())
+	r.Register(NewSmartRecruitersScraper())
+	r.Register(NewWorkableScraper())
+	r.Register(NewRecruiteeScraper())
+	r.Register(NewJSONLDScraper())
+	r.Register(NewHTMLScraper())
+
+	// External plugins add platforms; they never replace a built-in one.
+	for _, p := range discoveredPlugins() {
+		if _, exists := r.scrapers[p.Name()]; !exists {
+			r.Register(p)
+		}
+	}
 
 	return r
 }
 
+var (
+	pluginsOnce sync.Once
+	plugins     []*PluginScraper
+)
+
+// discoveredPlugins scans the plugin directories once per process and
+// reuses the result, so building a registry per command or per API request
+// stays cheap. Plugins installed later are picked up on the next start.
+func discoveredPlugins() []*PluginScraper {
+	pluginsOnce.Do(func() {
+		plugins = DiscoverPlugins(PluginDirs(), viper.GetDuration("plugins.timeout"))
+	})
+	return plugins
+}
+
 func (r *Registry) Register(s Scraper) {
 	r.scrapers[s.Name()] = s
 }
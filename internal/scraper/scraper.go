@@ -2,6 +2,11 @@ package scraper
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,10 +18,138 @@ type RawJob struct {
 	Remote		bool
 	Department	string
 	URL			string
+	// PostedAt is when the board says the job was first published.
 	PostedAt	*time.Time
+	// UpdatedAt is when the board says the job was last edited, for boards
+	// that report it separately.
+	UpdatedAt	*time.Time
+	// EmploymentType is the platform's own label (e.g. "FullTime", "Intern"), if it exposes one.
+	EmploymentType	string
+	// Compensation is the platform's human-readable pay summary (e.g. "$150K – $200K").
+	Compensation	string
+	// Raw is the platform's own payload for the posting, kept so stored jobs
+	// can be re-processed without scraping them again.
+	Raw	json.RawMessage
 }
 
+// Scraper fetches a board's open jobs. A scraper that could not reach every
+// posting returns the jobs it did get along with a *PartialError.
 type Scraper interface {
 	Name() string
 	FetchJobs(ctx context.Context, slug string) ([]RawJob, error)
+}
+
+// PartialError reports a crawl that stopped short of the whole board: a
+// page failed to load or a page limit was reached. The jobs returned with it
+// are usable, but a posting missing from them may still be open.
+type PartialError struct {
+	Source string
+	Reason string
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%s: incomplete crawl: %s", e.Source, e.Reason)
+}
+
+// IsPartial reports whether err only marks an incomplete crawl.
+func IsPartial(err error) bool {
+	var partial *PartialError
+	return errors.As(err, &partial)
+}
+
+// StatusError is returned when a job board answers with a non-200 status, so
+// callers can tell a wrong slug (404) from rate limiting (429).
+type StatusError struct {
+	Source     string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.Source, e.StatusCode)
+}
+
+// CareerPageScraper is implemented by scrapers that crawl a company's own
+// career site. They are handed Company.CareerURL instead of the slug.
+type CareerPageScraper interface {
+	Scraper
+	CrawlsCareerURL()
+}
+
+// Target returns the identifier s expects for a company: the career URL for
+// career page scrapers, the ATS slug for everything else.
+func Target(s Scraper, slug, careerURL string) string {
+	if _, ok := s.(CareerPageScraper); ok && careerURL != "" {
+		return careerURL
+	}
+	return slug
+}
+
+// PayloadDecoder is implemented by scrapers that can rebuild a posting from
+// the raw payload stored with it, so stored jobs can be re-derived without
+// scraping them again.
+type PayloadDecoder interface {
+	DecodePayload(raw json.RawMessage) (RawJob, error)
+}
+
+// decodePayload unmarshals a stored payload into T and converts it with fn.
+func decodePayload[T any](platform string, raw json.RawMessage, fn func(T) RawJob) (RawJob, error) {
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return RawJob{}, fmt.Errorf("decoding %s payload: %w", platform, err)
+	}
+	rj := fn(v)
+	rj.Raw = raw
+	return rj, nil
+}
+
+// payload decodes a JSON value into V and keeps the bytes it was decoded
+// from as the posting's raw payload.
+type payload[T any] struct {
+	V   T
+	Raw json.RawMessage
+}
+
+func (p *payload[T]) UnmarshalJSON(data []byte) error {
+	p.Raw = append(json.RawMessage(nil), data...)
+	return json.Unmarshal(data, &p.V)
+}
+
+// detailWorkers bounds concurrent posting-detail requests per board for
+// platforms whose list endpoint omits the description.
+const detailWorkers = 4
+
+// fetchAll runs fn for every index in [0, n) with at most workers calls in
+// flight, and returns the first error encountered.
+func fetchAll(n, workers int, fn func(i int) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	sem := make(chan struct{}, workers)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinNonEmpty joins the non-blank parts with sep, e.g. for "City, Region, Country" labels.
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
 }
\ No newline at end of file
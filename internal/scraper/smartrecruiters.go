@@ -0,0 +1,215 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// smartRecruitersPageSize is the largest page the postings API will return.
+const smartRecruitersPageSize = 100
+
+type SmartRecruitersScraper struct {
+	client *http.Client
+}
+
+func NewSmartRecruitersScraper() *SmartRecruitersScraper {
+	return &SmartRecruitersScraper{
+		client: newHTTPClient("smartrecruiters"),
+	}
+}
+
+func (s *SmartRecruitersScraper) Name() string {
+	return "smartrecruiters"
+}
+
+// SmartRecruiters posting API response structures
+type smartRecruitersPage struct {
+	Offset     int                      `json:"offset"`
+	TotalFound int                      `json:"totalFound"`
+	Content    []smartRecruitersPosting `json:"content"`
+}
+
+type smartRecruitersPosting struct {
+	ID               string                  `json:"id"`
+	Name             string                  `json:"name"`
+	ReleasedDate     string                  `json:"releasedDate"`
+	Location         smartRecruitersLocation `json:"location"`
+	Department       smartRecruitersLabel    `json:"department"`
+	Function         smartRecruitersLabel    `json:"function"`
+	TypeOfEmployment smartRecruitersLabel    `json:"typeOfEmployment"`
+}
+
+type smartRecruitersLocation struct {
+	City    string `json:"city"`
+	Region  string `json:"region"`
+	Country string `json:"country"`
+	Remote  bool   `json:"remote"`
+}
+
+type smartRecruitersLabel struct {
+	Label string `json:"label"`
+}
+
+type smartRecruitersDetail struct {
+	PostingURL string `json:"postingUrl"`
+	JobAd      struct {
+		Sections struct {
+			JobDescription        smartRecruitersSection `json:"jobDescription"`
+			Qualifications        smartRecruitersSection `json:"qualifications"`
+			AdditionalInformation smartRecruitersSection `json:"additionalInformation"`
+		} `json:"sections"`
+	} `json:"jobAd"`
+}
+
+type smartRecruitersSection struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+func (s *SmartRecruitersScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	var postings []smartRecruitersPosting
+	for offset := 0; ; {
+		url := fmt.Sprintf("https://api.smartrecruiters.com/v1/companies/%s/postings?limit=%d&offset=%d", slug, smartRecruitersPageSize, offset)
+		var page smartRecruitersPage
+		if err := s.getJSON(ctx, url, &page); err != nil {
+			return nil, fmt.Errorf("fetching smartrecruiters postings: %w", err)
+		}
+		postings = append(postings, page.Content...)
+		offset += len(page.Content)
+		if len(page.Content) == 0 || offset >= page.TotalFound {
+			break
+		}
+	}
+
+	// A posting whose detail fails to load is kept without its description
+	// rather than costing the whole company its crawl.
+	jobs := make([]RawJob, len(postings))
+	failed := make([]bool, len(postings))
+	_ = fetchAll(len(postings), detailWorkers, func(i int) error {
+		p := postings[i]
+		var detail payload[smartRecruitersDetail]
+		url := fmt.Sprintf("https://api.smartrecruiters.com/v1/companies/%s/postings/%s", slug, p.ID)
+		if err := s.getJSON(ctx, url, &detail); err != nil {
+			jobs[i] = smartRecruitersRawJob(slug, p, smartRecruitersDetail{})
+			failed[i] = true
+			return nil
+		}
+		jobs[i] = smartRecruitersRawJob(slug, p, detail.V)
+		jobs[i].Raw = detail.Raw
+		return nil
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	n := 0
+	for _, f := range failed {
+		if f {
+			n++
+		}
+	}
+	if n > 0 {
+		return jobs, &PartialError{Source: "smartrecruiters/" + slug, Reason: fmt.Sprintf("%d posting details failed to load", n)}
+	}
+	return jobs, nil
+}
+
+func (s *SmartRecruitersScraper) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{Source: "smartrecruiters API", StatusCode: resp.StatusCode}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding smartrecruiters response: %w", err)
+	}
+	return nil
+}
+
+func smartRecruitersRawJob(slug string, p smartRecruitersPosting, d smartRecruitersDetail) RawJob {
+	var parts []string
+	for _, sec := range []smartRecruitersSection{
+		d.JobAd.Sections.JobDescription,
+		d.JobAd.Sections.Qualifications,
+		d.JobAd.Sections.AdditionalInformation,
+	} {
+		if sec.Text == "" {
+			continue
+		}
+		parts = append(parts, "<h3>"+html.EscapeString(sec.Title)+"</h3>"+sec.Text)
+	}
+
+	var postedAt *time.Time
+	if t, err := time.Parse(time.RFC3339, p.ReleasedDate); err == nil {
+		postedAt = &t
+	}
+
+	department := p.Department.Label
+	if department == "" {
+		department = p.Function.Label
+	}
+
+	url := d.PostingURL
+	if url == "" {
+		url = fmt.Sprintf("https://jobs.smartrecruiters.com/%s/%s", slug, p.ID)
+	}
+
+	return RawJob{
+		ExternalID:     p.ID,
+		Title:          p.Name,
+		Description:    strings.Join(parts, "\n"),
+		Location:       joinNonEmpty(", ", p.Location.City, p.Location.Region, strings.ToUpper(p.Location.Country)),
+		Remote:         p.Location.Remote,
+		Department:     department,
+		URL:            url,
+		PostedAt:       postedAt,
+		EmploymentType: p.TypeOfEmployment.Label,
+	}
+}
+
+// DecodePayload implements PayloadDecoder. The stored payload is the
+// posting's detail, which repeats the fields of its list entry.
+func (s *SmartRecruitersScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	var p smartRecruitersPosting
+	var d smartRecruitersDetail
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return RawJob{}, fmt.Errorf("decoding smartrecruiters payload: %w", err)
+	}
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return RawJob{}, fmt.Errorf("decoding smartrecruiters payload: %w", err)
+	}
+	rj := smartRecruitersRawJob("", p, d)
+	rj.Raw = raw
+	return rj, nil
+}
+
+// SlugFromURL recognises jobs.smartrecruiters.com/<slug> and the postings API.
+func (s *SmartRecruitersScraper) SlugFromURL(u *url.URL) (string, bool) {
+	segs := pathSegments(u)
+	switch strings.ToLower(u.Hostname()) {
+	case "jobs.smartrecruiters.com", "careers.smartrecruiters.com":
+		if len(segs) > 0 {
+			return segs[0], true
+		}
+	case "api.smartrecruiters.com":
+		if len(segs) > 2 && segs[0] == "v1" && segs[1] == "companies" {
+			return segs[2], true
+		}
+	}
+	return "", false
+}
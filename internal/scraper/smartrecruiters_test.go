@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSmartRecruitersFetchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	scraper := NewSmartRecruitersScraper()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Use a known SmartRecruiters company
+	jobs, err := scraper.FetchJobs(ctx, "Visa")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+
+	t.Logf("Found %d jobs from SmartRecruiters/visa", len(jobs))
+
+	if len(jobs) == 0 {
+		t.Log("Warning: no jobs returned")
+		return
+	}
+
+	j := jobs[0]
+	if j.ExternalID == "" {
+		t.Error("ExternalID is empty")
+	}
+	if j.Title == "" {
+		t.Error("Title is empty")
+	}
+	if j.URL == "" {
+		t.Error("URL is empty")
+	}
+
+	t.Logf("Sample job: %s — %s (%s) [%s] posted %v", j.Title, j.Location, j.URL, j.Department, j.PostedAt)
+}
+
+func TestSmartRecruitersFetchJobsKeepsPostingsWithoutDetail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/companies/acme/postings":
+			_, _ = w.Write([]byte(`{"offset":0,"totalFound":2,"content":[
+				{"id":"1","name":"Backend Engineer"},
+				{"id":"2","name":"Data Engineer"}]}`))
+		case "/v1/companies/acme/postings/1":
+			_, _ = w.Write([]byte(`{"id":"1","name":"Backend Engineer","postingUrl":"https://jobs.smartrecruiters.com/acme/1-backend",
+				"jobAd":{"sections":{"jobDescription":{"title":"About","text":"<p>Go</p>"}}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	s := &SmartRecruitersScraper{client: serverClient(srv)}
+	jobs, err := s.FetchJobs(context.Background(), "acme")
+	if !IsPartial(err) {
+		t.Fatalf("err = %v, want a partial crawl", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want both postings", len(jobs))
+	}
+	if jobs[0].Description == "" || jobs[0].URL != "https://jobs.smartrecruiters.com/acme/1-backend" {
+		t.Errorf("first job = %+v, want its detail", jobs[0])
+	}
+	if jobs[1].Title != "Data Engineer" || jobs[1].Description != "" || jobs[1].Raw != nil {
+		t.Errorf("second job = %+v, want the list entry without detail", jobs[1])
+	}
+}
+
+func TestSmartRecruitersDecodePayload(t *testing.T) {
+	raw := []byte(`{"id":"744","name":"Backend Engineer","releasedDate":"2026-03-01T12:00:00.000Z",
+		"location":{"city":"Austin","region":"TX","country":"us","remote":true},
+		"department":{},"function":{"label":"Engineering"},"typeOfEmployment":{"label":"Full-time"},
+		"postingUrl":"https://jobs.smartrecruiters.com/acme/744-backend-engineer",
+		"jobAd":{"sections":{"jobDescription":{"title":"About","text":"<p>Go</p>"},"qualifications":{"title":"You have","text":"<p>SQL</p>"}}}}`)
+
+	j, err := NewSmartRecruitersScraper().DecodePayload(raw)
+	if err != nil {
+		t.Fatalf("DecodePayload error: %v", err)
+	}
+	if j.ExternalID != "744" || j.Location != "Austin, TX, US" || !j.Remote || j.EmploymentType != "Full-time" {
+		t.Errorf("decoded %+v", j)
+	}
+	if j.Department != "Engineering" {
+		t.Errorf("Department = %q, want the function when no department is set", j.Department)
+	}
+	if j.PostedAt == nil || !j.PostedAt.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("PostedAt = %v", j.PostedAt)
+	}
+	if j.Description != "<h3>About</h3><p>Go</p>\n<h3>You have</h3><p>SQL</p>" {
+		t.Errorf("Description = %q", j.Description)
+	}
+	if j.URL != "https://jobs.smartrecruiters.com/acme/744-backend-engineer" {
+		t.Errorf("URL = %q", j.URL)
+	}
+	if string(j.Raw) != string(raw) {
+		t.Error("Expected Raw to be the stored payload")
+	}
+
+	if _, err := NewSmartRecruitersScraper().DecodePayload([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for a malformed payload")
+	}
+}
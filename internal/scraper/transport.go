@@ -0,0 +1,275 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Politeness defaults, overridable under http.* in config.yaml.
+const (
+	defaultUserAgent   = "jobgo (+https://github.com/Trungsherlock/jobgo)"
+	defaultRPS         = 2.0
+	defaultMaxRetries  = 4
+	defaultBaseBackoff = 500 * time.Millisecond
+	defaultMaxBackoff  = 30 * time.Second
+	defaultHTTPTimeout = 30 * time.Second
+	// maxRetryAfter is the longest Retry-After we will wait out; beyond it
+	// the throttled response is returned to the caller.
+	maxRetryAfter = 2 * time.Minute
+)
+
+// limiters holds one limiter per platform and host, so every scraper
+// instance and worker hitting the same host shares a single request budget,
+// while unrelated company sites scraped through one platform (html, jsonld)
+// each get their own.
+var (
+	limitersMu sync.Mutex
+	limiters   = make(map[limiterKey]*limiter)
+)
+
+type limiterKey struct {
+	platform string
+	host     string
+}
+
+// newHTTPClient returns the client a platform's scraper should use. It is
+// configured from viper:
+//
+//	http:
+//	  user_agent: "jobgo (+https://example.com)"
+//	  proxy: http://127.0.0.1:8080
+//	  timeout: 30s          # per attempt
+//	  max_retries: 4
+//	  base_backoff: 500ms
+//	  max_backoff: 30s
+//	  rate_limits:          # requests per second, per host
+//	    default: 2
+//	    workday: 1
+func newHTTPClient(platform string) *http.Client {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy := viper.GetString("http.proxy"); proxy != "" {
+		if u, err := url.Parse(proxy); err == nil {
+			base.Proxy = http.ProxyURL(u)
+		}
+	}
+
+	t := &politeTransport{
+		base:        base,
+		platform:    platform,
+		rps:         platformRate(platform),
+		userAgent:   viper.GetString("http.user_agent"),
+		timeout:     viper.GetDuration("http.timeout"),
+		maxRetries:  defaultMaxRetries,
+		baseBackoff: viper.GetDuration("http.base_backoff"),
+		maxBackoff:  viper.GetDuration("http.max_backoff"),
+	}
+	if t.userAgent == "" {
+		t.userAgent = defaultUserAgent
+	}
+	if t.timeout <= 0 {
+		t.timeout = defaultHTTPTimeout
+	}
+	if viper.IsSet("http.max_retries") {
+		t.maxRetries = viper.GetInt("http.max_retries")
+	}
+	if t.baseBackoff <= 0 {
+		t.baseBackoff = defaultBaseBackoff
+	}
+	if t.maxBackoff <= 0 {
+		t.maxBackoff = defaultMaxBackoff
+	}
+
+	// Timeouts are enforced per attempt by the transport; a client-wide
+	// timeout would also count the time spent backing off.
+	return &http.Client{Transport: t}
+}
+
+// platformRate reads the configured requests per second for platform.
+func platformRate(platform string) float64 {
+	rps := viper.GetFloat64("http.rate_limits." + platform)
+	if rps <= 0 {
+		rps = viper.GetFloat64("http.rate_limits.default")
+	}
+	if rps <= 0 {
+		rps = defaultRPS
+	}
+	return rps
+}
+
+// hostLimiter returns the shared limiter for a platform's host, set to rps.
+// Clients are built per scrape run, so a changed rate in config takes effect
+// on the next run without losing the spacing of requests already queued.
+func hostLimiter(platform, host string, rps float64) *limiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	key := limiterKey{platform, host}
+	if l, ok := limiters[key]; ok {
+		l.setRate(rps)
+		return l
+	}
+	l := newLimiter(rps)
+	limiters[key] = l
+	return l
+}
+
+// politeTransport rate limits requests, sets a User-Agent and retries 429
+// and 5xx responses with exponential backoff and jitter, honouring
+// Retry-After when the server sends one.
+type politeTransport struct {
+	base        http.RoundTripper
+	platform    string
+	rps         float64
+	userAgent   string
+	timeout     time.Duration
+	maxRetries  int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func (t *politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := hostLimiter(t.platform, req.URL.Host, t.rps)
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		r := req.Clone(req.Context())
+		if r.Header.Get("User-Agent") == "" {
+			r.Header.Set("User-Agent", t.userAgent)
+		}
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+		resp, err := t.base.RoundTrip(r.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		wait, retry := t.retryAfter(resp, attempt)
+		if !retry || (req.Body != nil && req.GetBody == nil) {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+		cancel()
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// retryAfter reports whether resp should be retried and how long to wait.
+func (t *politeTransport) retryAfter(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return 0, false
+	}
+	if attempt >= t.maxRetries {
+		return 0, false
+	}
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		if d > maxRetryAfter {
+			return 0, false
+		}
+		return d, true
+	}
+	return t.backoff(attempt), true
+}
+
+// backoff is base·2^attempt capped at maxBackoff, with the upper half
+// randomised so concurrent workers don't retry in lockstep.
+func (t *politeTransport) backoff(attempt int) time.Duration {
+	d := t.baseBackoff << attempt
+	if d <= 0 || d > t.maxBackoff {
+		d = t.maxBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// parseRetryAfter accepts both the delay-seconds and HTTP-date forms.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// limiter spaces requests at least 1/rps apart.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(rps float64) *limiter {
+	return &limiter{interval: time.Duration(float64(time.Second) / rps)}
+}
+
+func (l *limiter) setRate(rps float64) {
+	l.mu.Lock()
+	l.interval = time.Duration(float64(time.Second) / rps)
+	l.mu.Unlock()
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
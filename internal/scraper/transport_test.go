@@ -0,0 +1,182 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestMain(m *testing.M) {
+	// Local test servers don't need protecting from ourselves.
+	viper.Set("http.rate_limits.default", 1000)
+	os.Exit(m.Run())
+}
+
+func testTransport() *politeTransport {
+	return &politeTransport{
+		base:        http.DefaultTransport,
+		platform:    "test",
+		rps:         1000,
+		userAgent:   "jobgo-test",
+		timeout:     5 * time.Second,
+		maxRetries:  3,
+		baseBackoff: time.Millisecond,
+		maxBackoff:  5 * time.Millisecond,
+	}
+}
+
+// serverClient sends every request to srv whatever its host, so scrapers with
+// fixed API hosts can be run against a local fixture.
+func serverClient(srv *httptest.Server) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = "http", strings.TrimPrefix(srv.URL, "http://")
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestPoliteTransport_RetriesThrottledAndServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "jobgo-test" {
+			t.Errorf("User-Agent = %q", r.Header.Get("User-Agent"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == "POST" && string(body) != `{"q":1}` {
+			t.Errorf("attempt %d body = %q", calls.Load(), body)
+		}
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = io.WriteString(w, "ok")
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: testTransport()}
+	req, _ := http.NewRequest("POST", srv.URL, strings.NewReader(`{"q":1}`))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+		t.Errorf("got %d %q, want 200 ok", resp.StatusCode, body)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("server saw %d calls, want 3", calls.Load())
+	}
+}
+
+func TestPoliteTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	resp, err := (&http.Client{Transport: testTransport()}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 4 {
+		t.Errorf("got status %d after %d calls, want 503 after 4", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestPoliteTransport_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	resp, err := (&http.Client{Transport: testTransport()}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("404 was requested %d times, want 1", calls.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"Fri, 01 Mar 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Fri, 01 Mar 2024 11:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoffIsBoundedAndJittered(t *testing.T) {
+	tr := &politeTransport{baseBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+	for attempt := 0; attempt < 10; attempt++ {
+		want := 100 * time.Millisecond << attempt
+		if want > time.Second {
+			want = time.Second
+		}
+		if d := tr.backoff(attempt); d < want/2 || d > want {
+			t.Errorf("backoff(%d) = %v, want in [%v, %v]", attempt, d, want/2, want)
+		}
+	}
+}
+
+func TestHostLimiterPerHost(t *testing.T) {
+	a := hostLimiter("html", "careers.a.example", 5)
+	if b := hostLimiter("html", "jobs.b.example", 5); a == b {
+		t.Error("unrelated hosts share a limiter")
+	}
+	if again := hostLimiter("html", "careers.a.example", 10); again != a {
+		t.Error("same host got a new limiter")
+	} else if a.interval != 100*time.Millisecond {
+		t.Errorf("interval = %v, want the new rate's 100ms", a.interval)
+	}
+}
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	l := newLimiter(50)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("5 requests at 50 rps took %v, want >= 80ms", elapsed)
+	}
+}
@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type WorkableScraper struct {
+	client *http.Client
+}
+
+func NewWorkableScraper() *WorkableScraper {
+	return &WorkableScraper{
+		client: newHTTPClient("workable"),
+	}
+}
+
+func (w *WorkableScraper) Name() string {
+	return "workable"
+}
+
+// Workable widget API response structure
+type workableAccount struct {
+	Jobs []payload[workableJob] `json:"jobs"`
+}
+
+type workableJob struct {
+	Title          string             `json:"title"`
+	Shortcode      string             `json:"shortcode"`
+	EmploymentType string             `json:"employment_type"`
+	Telecommuting  bool               `json:"telecommuting"`
+	Department     string             `json:"department"`
+	URL            string             `json:"url"`
+	PublishedOn    string             `json:"published_on"`
+	CreatedAt      string             `json:"created_at"`
+	City           string             `json:"city"`
+	State          string             `json:"state"`
+	Country        string             `json:"country"`
+	Locations      []workableLocation `json:"locations"`
+	Description    string             `json:"description"`
+}
+
+type workableLocation struct {
+	City    string `json:"city"`
+	Region  string `json:"region"`
+	Country string `json:"country"`
+	Hidden  bool   `json:"hidden"`
+}
+
+func (w *WorkableScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	jobs, _, err := w.FetchJobsIfChanged(ctx, slug, Validators{})
+	return jobs, err
+}
+
+// FetchJobsIfChanged implements ConditionalScraper.
+func (w *WorkableScraper) FetchJobsIfChanged(ctx context.Context, slug string, prev Validators) ([]RawJob, Validators, error) {
+	url := fmt.Sprintf("https://apply.workable.com/api/v1/widget/accounts/%s?details=true", slug)
+	resp, next, err := getIfChanged(ctx, w.client, url, prev)
+	if err != nil {
+		return nil, next, fmt.Errorf("fetching workable jobs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, next, &StatusError{Source: "workable API", StatusCode: resp.StatusCode}
+	}
+
+	var account workableAccount
+	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		return nil, next, fmt.Errorf("decoding workable response: %w", err)
+	}
+
+	jobs := make([]RawJob, 0, len(account.Jobs))
+	for _, pj := range account.Jobs {
+		rj := workableRawJob(pj.V)
+		rj.Raw = pj.Raw
+		jobs = append(jobs, rj)
+	}
+
+	return jobs, next, nil
+}
+
+func workableRawJob(j workableJob) RawJob {
+	var postedAt *time.Time
+	for _, d := range []string{j.PublishedOn, j.CreatedAt} {
+		if t, err := time.Parse("2006-01-02", d); err == nil {
+			postedAt = &t
+			break
+		}
+	}
+
+	var locations []string
+	for _, l := range j.Locations {
+		if l.Hidden {
+			continue
+		}
+		locations = append(locations, joinNonEmpty(", ", l.City, l.Region, l.Country))
+	}
+	if len(locations) == 0 {
+		locations = append(locations, joinNonEmpty(", ", j.City, j.State, j.Country))
+	}
+	location := strings.Join(locations, "; ")
+
+	return RawJob{
+		ExternalID:     j.Shortcode,
+		Title:          j.Title,
+		Description:    j.Description,
+		Location:       location,
+		Remote:         j.Telecommuting || strings.Contains(strings.ToLower(location), "remote"),
+		Department:     j.Department,
+		URL:            j.URL,
+		PostedAt:       postedAt,
+		EmploymentType: j.EmploymentType,
+	}
+}
+
+// DecodePayload implements PayloadDecoder.
+func (w *WorkableScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("workable", raw, workableRawJob)
+}
+
+// SlugFromURL recognises apply.workable.com/<slug> and the widget API.
+func (w *WorkableScraper) SlugFromURL(u *url.URL) (string, bool) {
+	if strings.ToLower(u.Hostname()) != "apply.workable.com" {
+		return "", false
+	}
+	segs := pathSegments(u)
+	if len(segs) > 4 && segs[0] == "api" && segs[3] == "accounts" {
+		return segs[4], true
+	}
+	if len(segs) > 0 && segs[0] != "api" && segs[0] != "j" {
+		return segs[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWorkableFetchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	scraper := NewWorkableScraper()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Workable hosts its own careers page on Workable
+	jobs, err := scraper.FetchJobs(ctx, "workable")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+
+	t.Logf("Found %d jobs from Workable/workable", len(jobs))
+
+	if len(jobs) == 0 {
+		t.Log("Warning: no jobs returned")
+		return
+	}
+
+	j := jobs[0]
+	if j.ExternalID == "" {
+		t.Error("ExternalID is empty")
+	}
+	if j.Title == "" {
+		t.Error("Title is empty")
+	}
+	if j.URL == "" {
+		t.Error("URL is empty")
+	}
+
+	t.Logf("Sample job: %s — %s (%s) [%s] posted %v", j.Title, j.Location, j.URL, j.Department, j.PostedAt)
+}
+
+func TestWorkableDecodePayload(t *testing.T) {
+	raw := []byte(`{"title":"Backend Engineer","shortcode":"AB12CD","employment_type":"Full-time","telecommuting":true,
+		"department":"Engineering","url":"https://apply.workable.com/j/AB12CD","published_on":"2026-03-01",
+		"city":"Berlin","country":"Germany",
+		"locations":[{"city":"Berlin","country":"Germany","hidden":true},{"city":"Lisbon","country":"Portugal"}],
+		"description":"<p>Go</p>"}`)
+
+	j, err := NewWorkableScraper().DecodePayload(raw)
+	if err != nil {
+		t.Fatalf("DecodePayload error: %v", err)
+	}
+	if j.ExternalID != "AB12CD" || !j.Remote || j.EmploymentType != "Full-time" || j.Department != "Engineering" {
+		t.Errorf("decoded %+v", j)
+	}
+	if j.Location != "Lisbon, Portugal" {
+		t.Errorf("Location = %q, want hidden locations left out", j.Location)
+	}
+	if j.PostedAt == nil || !j.PostedAt.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("PostedAt = %v", j.PostedAt)
+	}
+	if string(j.Raw) != string(raw) {
+		t.Error("Expected Raw to be the stored payload")
+	}
+
+	// With every location hidden the posting falls back to its own city.
+	j, _ = NewWorkableScraper().DecodePayload([]byte(`{"shortcode":"X","telecommuting":true,"city":"Berlin","country":"Germany",
+		"locations":[{"city":"Munich","country":"Germany","hidden":true}]}`))
+	if j.Location != "Berlin, Germany" || !j.Remote {
+		t.Errorf("all hidden: Location = %q, Remote = %v", j.Location, j.Remote)
+	}
+
+	if _, err := NewWorkableScraper().DecodePayload([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for a malformed payload")
+	}
+}
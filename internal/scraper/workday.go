@@ -0,0 +1,318 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// workdayPageSize is the largest page the Workday CXS API will return.
+const workdayPageSize = 20
+
+type WorkdayScraper struct {
+	client *http.Client
+}
+
+func NewWorkdayScraper() *WorkdayScraper {
+	return &WorkdayScraper{
+		client: newHTTPClient("workday"),
+	}
+}
+
+func (w *WorkdayScraper) Name() string {
+	return "workday"
+}
+
+// workdaySite identifies a Workday career site. Slugs take the form
+// "<tenant>.<wdN>/<site>", e.g. "nvidia.wd5/NVIDIAExternalCareerSite";
+// a full "<tenant>.<wdN>.myworkdayjobs.com/<site>" host is also accepted.
+type workdaySite struct {
+	Host   string
+	Tenant string
+	Site   string
+}
+
+func parseWorkdaySlug(slug string) (workdaySite, error) {
+	slug = strings.TrimPrefix(strings.TrimPrefix(slug, "https://"), "http://")
+	slug = strings.Trim(slug, "/")
+
+	host, path, ok := strings.Cut(slug, "/")
+	if !ok || host == "" || path == "" {
+		return workdaySite{}, fmt.Errorf("invalid workday slug %q: want <tenant>.<wdN>/<site>", slug)
+	}
+
+	// Career site URLs may carry a locale segment, e.g. /en-US/<site>/...
+	segments := strings.Split(path, "/")
+	site := segments[0]
+	if len(segments) > 1 && localeRE.MatchString(site) {
+		site = segments[1]
+	}
+
+	if !strings.Contains(host, ".") {
+		return workdaySite{}, fmt.Errorf("invalid workday slug %q: missing data center (e.g. %s.wd5)", slug, host)
+	}
+	if !strings.HasSuffix(host, ".myworkdayjobs.com") {
+		host += ".myworkdayjobs.com"
+	}
+	tenant, _, _ := strings.Cut(host, ".")
+
+	return workdaySite{Host: host, Tenant: tenant, Site: site}, nil
+}
+
+var localeRE = regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`)
+
+func (s workdaySite) apiURL(path string) string {
+	return fmt.Sprintf("https://%s/wday/cxs/%s/%s%s", s.Host, s.Tenant, s.Site, path)
+}
+
+// Workday CXS API response structures
+type workdaySearchRequest struct {
+	AppliedFacets map[string]any `json:"appliedFacets"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	SearchText    string         `json:"searchText"`
+}
+
+type workdaySearchResponse struct {
+	Total       int                 `json:"total"`
+	JobPostings []workdayJobPosting `json:"jobPostings"`
+}
+
+type workdayJobPosting struct {
+	Title         string   `json:"title"`
+	ExternalPath  string   `json:"externalPath"`
+	LocationsText string   `json:"locationsText"`
+	PostedOn      string   `json:"postedOn"`
+	BulletFields  []string `json:"bulletFields"`
+}
+
+type workdayJobDetail struct {
+	JobPostingInfo workdayJobPostingInfo `json:"jobPostingInfo"`
+}
+
+type workdayJobPostingInfo struct {
+	ID                  string   `json:"id"`
+	Title               string   `json:"title"`
+	JobDescription      string   `json:"jobDescription"`
+	Location            string   `json:"location"`
+	AdditionalLocations []string `json:"additionalLocations"`
+	PostedOn            string   `json:"postedOn"`
+	StartDate           string   `json:"startDate"`
+	TimeType            string   `json:"timeType"`
+	RemoteType          string   `json:"remoteType"`
+	JobReqID            string   `json:"jobReqId"`
+	ExternalURL         string   `json:"externalUrl"`
+}
+
+func (w *WorkdayScraper) FetchJobs(ctx context.Context, slug string) ([]RawJob, error) {
+	site, err := parseWorkdaySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	postings, err := w.listPostings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]RawJob, len(postings))
+	err = fetchAll(len(postings), detailWorkers, func(i int) error {
+		p := postings[i]
+		detail, err := w.fetchDetail(ctx, site, p.ExternalPath)
+		if err != nil {
+			return fmt.Errorf("fetching workday posting %s: %w", p.ExternalPath, err)
+		}
+		jobs[i] = workdayRawJob(site, p, detail.V.JobPostingInfo)
+		jobs[i].Raw = detail.Raw
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// listPostings pages through the full search result set. Workday only reports
+// the total on the first page, so it is captured once; a short page also ends the scan.
+func (w *WorkdayScraper) listPostings(ctx context.Context, site workdaySite) ([]workdayJobPosting, error) {
+	var postings []workdayJobPosting
+	total := 0
+	for offset := 0; ; {
+		page, err := w.searchPage(ctx, site, offset)
+		if err != nil {
+			return nil, err
+		}
+		if offset == 0 {
+			total = page.Total
+		}
+		postings = append(postings, page.JobPostings...)
+		offset += len(page.JobPostings)
+
+		if len(page.JobPostings) < workdayPageSize || (total > 0 && offset >= total) {
+			break
+		}
+	}
+	return postings, nil
+}
+
+func (w *WorkdayScraper) searchPage(ctx context.Context, site workdaySite, offset int) (*workdaySearchResponse, error) {
+	body, err := json.Marshal(workdaySearchRequest{
+		AppliedFacets: map[string]any{},
+		Limit:         workdayPageSize,
+		Offset:        offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", site.apiURL("/jobs"), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching workday jobs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Source: "workday API", StatusCode: resp.StatusCode}
+	}
+
+	var page workdaySearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("decoding workday response: %w", err)
+	}
+	return &page, nil
+}
+
+func (w *WorkdayScraper) fetchDetail(ctx context.Context, site workdaySite, externalPath string) (*payload[workdayJobDetail], error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", site.apiURL(externalPath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Source: "workday API", StatusCode: resp.StatusCode}
+	}
+
+	var detail payload[workdayJobDetail]
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return nil, fmt.Errorf("decoding workday posting: %w", err)
+	}
+	return &detail, nil
+}
+
+func workdayRawJob(site workdaySite, p workdayJobPosting, info workdayJobPostingInfo) RawJob {
+	externalID := info.JobReqID
+	if externalID == "" && len(p.BulletFields) > 0 {
+		externalID = p.BulletFields[0]
+	}
+	if externalID == "" {
+		externalID = p.ExternalPath
+	}
+
+	title := info.Title
+	if title == "" {
+		title = p.Title
+	}
+
+	location := info.Location
+	if location == "" {
+		location = p.LocationsText
+	}
+	if len(info.AdditionalLocations) > 0 {
+		location = strings.Join(append([]string{location}, info.AdditionalLocations...), "; ")
+	}
+
+	url := info.ExternalURL
+	if url == "" {
+		url = fmt.Sprintf("https://%s/%s%s", site.Host, site.Site, p.ExternalPath)
+	}
+
+	var postedAt *time.Time
+	if t, err := time.Parse("2006-01-02", info.StartDate); err == nil {
+		postedAt = &t
+	} else if t, ok := parseWorkdayPostedOn(p.PostedOn, time.Now()); ok {
+		postedAt = &t
+	}
+
+	remote := strings.EqualFold(info.RemoteType, "remote") ||
+		strings.Contains(strings.ToLower(location), "remote")
+
+	return RawJob{
+		ExternalID:     externalID,
+		Title:          title,
+		Description:    info.JobDescription,
+		Location:       location,
+		Remote:         remote,
+		URL:            url,
+		PostedAt:       postedAt,
+		EmploymentType: info.TimeType,
+	}
+}
+
+// DecodePayload implements PayloadDecoder. The stored payload is the
+// posting's detail; fields only its search result carries are left empty.
+func (w *WorkdayScraper) DecodePayload(raw json.RawMessage) (RawJob, error) {
+	return decodePayload("workday", raw, func(d workdayJobDetail) RawJob {
+		return workdayRawJob(workdaySite{}, workdayJobPosting{}, d.JobPostingInfo)
+	})
+}
+
+var workdayDaysAgoRE = regexp.MustCompile(`(?i)posted\s+(\d+)\+?\s+days?\s+ago`)
+
+// parseWorkdayPostedOn converts Workday's relative "Posted 3 Days Ago" labels
+// into a date. "30+ Days Ago" is treated as exactly 30 days.
+func parseWorkdayPostedOn(s string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case lower == "posted today":
+		return today, true
+	case lower == "posted yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	if m := workdayDaysAgoRE.FindStringSubmatch(lower); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return today.AddDate(0, 0, -n), true
+	}
+	return time.Time{}, false
+}
+
+// SlugFromURL recognises <tenant>.<wdN>.myworkdayjobs.com career site URLs,
+// with or without a locale segment, and the cxs API URL.
+func (w *WorkdayScraper) SlugFromURL(u *url.URL) (string, bool) {
+	host := strings.ToLower(u.Hostname())
+	if !strings.HasSuffix(host, ".myworkdayjobs.com") {
+		return "", false
+	}
+	segs := pathSegments(u)
+	if len(segs) > 3 && segs[0] == "wday" && segs[1] == "cxs" {
+		segs = segs[3:]
+	}
+	if len(segs) > 1 && localeRE.MatchString(segs[0]) {
+		segs = segs[1:]
+	}
+	if len(segs) == 0 {
+		return "", false
+	}
+	return strings.TrimSuffix(host, ".myworkdayjobs.com") + "/" + segs[0], true
+}
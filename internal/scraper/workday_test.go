@@ -0,0 +1,99 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseWorkdaySlug(t *testing.T) {
+	tests := []struct {
+		slug     string
+		wantHost string
+		wantSite string
+		wantErr  bool
+	}{
+		{"nvidia.wd5/NVIDIAExternalCareerSite", "nvidia.wd5.myworkdayjobs.com", "NVIDIAExternalCareerSite", false},
+		{"https://nvidia.wd5.myworkdayjobs.com/en-US/NVIDIAExternalCareerSite", "nvidia.wd5.myworkdayjobs.com", "NVIDIAExternalCareerSite", false},
+		{"salesforce.wd12.myworkdayjobs.com/External_Career_Site/", "salesforce.wd12.myworkdayjobs.com", "External_Career_Site", false},
+		{"nvidia/NVIDIAExternalCareerSite", "", "", true},
+		{"nvidia.wd5", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			site, err := parseWorkdaySlug(tt.slug)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %+v", site)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWorkdaySlug: %v", err)
+			}
+			if site.Host != tt.wantHost || site.Site != tt.wantSite {
+				t.Errorf("got host=%s site=%s, want %s/%s", site.Host, site.Site, tt.wantHost, tt.wantSite)
+			}
+		})
+	}
+}
+
+func TestParseWorkdayPostedOn(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+		ok    bool
+	}{
+		{"Posted Today", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), true},
+		{"Posted Yesterday", time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC), true},
+		{"Posted 3 Days Ago", time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), true},
+		{"Posted 30+ Days Ago", time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := parseWorkdayPostedOn(tt.input, now)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("parseWorkdayPostedOn(%q) = %v, %v; want %v, %v", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestWorkdayFetchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	scraper := NewWorkdayScraper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	jobs, err := scraper.FetchJobs(ctx, "nvidia.wd5/NVIDIAExternalCareerSite")
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+
+	t.Logf("Found %d jobs from Workday/nvidia", len(jobs))
+
+	if len(jobs) == 0 {
+		t.Log("Warning: no jobs returned")
+		return
+	}
+
+	j := jobs[0]
+	if j.ExternalID == "" {
+		t.Error("ExternalID is empty")
+	}
+	if j.Description == "" {
+		t.Error("Description is empty")
+	}
+	if j.URL == "" {
+		t.Error("URL is empty")
+	}
+
+	t.Logf("Sample job: %s — %s (%s)", j.Title, j.Location, j.URL)
+}
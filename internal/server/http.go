@@ -6,14 +6,20 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/dedup"
+	"github.com/Trungsherlock/jobgo/internal/freshness"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
 	"github.com/Trungsherlock/jobgo/internal/scraper"
 	"github.com/Trungsherlock/jobgo/internal/worker"
 	"github.com/Trungsherlock/jobgo/internal/filter"
 	"github.com/Trungsherlock/jobgo/internal/matcher"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/spf13/viper"
 )
 
 type Server struct {
@@ -36,8 +42,12 @@ func (s *Server) setupRoutes() {
 	r.Route("/api", func(r chi.Router) {
 		r.Get("/jobs", s.listJobs)
 		r.Get("/jobs/{id}", s.getJob)
+		r.Get("/jobs/{id}/duplicates", s.getJobDuplicates)
 		r.Get("/companies", s.listCompanies)
-		r.Post("/companies", s.addCompany)
+		// Detection fetches the URL it is given, so only the extension and
+		// configured origins may ask for it from a browser.
+		r.With(requireAllowedOrigin).Post("/companies", s.addCompany)
+		r.With(requireAllowedOrigin).Post("/companies/detect", s.detectCompany)
 		r.Delete("/companies/{id}", s.deleteCompany)
 		r.Get("/profile", s.getProfile)
 		r.Get("/stats", s.getStats)
@@ -69,9 +79,25 @@ func (s *Server) listJobs(w http.ResponseWriter, r *http.Request) {
     h1bOnly := r.URL.Query().Get("h1b") == "true"
     newGrad := r.URL.Query().Get("new_grad") == "true"
     inCart := r.URL.Query().Get("in_cart") == "true"
+    includeClosed := r.URL.Query().Get("include_closed") == "true"
+    expand := r.URL.Query().Get("expand") == "true"
+    minSalary, _ := strconv.ParseFloat(r.URL.Query().Get("min_salary"), 64)
+    salaryCurrency := r.URL.Query().Get("salary_currency")
+    employmentType := r.URL.Query().Get("employment_type")
+    excludeRestricted := r.URL.Query().Get("exclude_restricted") == "true"
+    postedWithin := r.URL.Query().Get("posted_within")
+    sortKey := r.URL.Query().Get("sort")
 
     // SQL handles score + status
-    jobs, err := s.db.ListJobs(minScore, companyID, onlyNew, false, false, false, inCart)
+    jobs, err := s.db.QueryJobs(database.JobQuery{
+        MinScore:      minScore,
+        CompanyID:     companyID,
+        OnlyNew:       onlyNew,
+        InCartOnly:    inCart,
+        IncludeClosed: includeClosed,
+        MinSalary:     minSalary,
+        SalaryCurrency: salaryCurrency,
+    })
     if err != nil {
         writeError(w, http.StatusInternalServerError, err.Error())
         return
@@ -85,8 +111,18 @@ func (s *Server) listJobs(w http.ResponseWriter, r *http.Request) {
     if locationParam != "" {
         params.Locations = strings.Split(locationParam, ",")
     }
+    if employmentType != "" {
+        params.EmploymentTypes = strings.Split(employmentType, ",")
+    }
     params.NewGrad = newGrad
     params.H1BOnly = h1bOnly
+    params.ExcludeRestricted = excludeRestricted
+    if postedWithin != "" {
+        if params.PostedWithin, err = freshness.ParseAge(postedWithin); err != nil {
+            writeError(w, http.StatusBadRequest, err.Error())
+            return
+        }
+    }
 
     var sponsorIDs map[string]bool
     if h1bOnly {
@@ -100,6 +136,13 @@ func (s *Server) listJobs(w http.ResponseWriter, r *http.Request) {
     }
 
     jobs = filter.Apply(jobs, filter.Build(params, sponsorIDs))
+    if jobs, err = ranking.Sort(jobs, sortKey, time.Now()); err != nil {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+    if !expand {
+        jobs = dedup.Collapse(jobs)
+    }
     writeJSON(w, http.StatusOK, jobs)
 }
 
@@ -115,6 +158,15 @@ func (s *Server) getJob(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, job)
 }
 
+func (s *Server) getJobDuplicates(w http.ResponseWriter, r *http.Request) {
+	jobs, err := s.db.ListJobGroup(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, jobs)
+}
+
 func (s *Server) listCompanies(w http.ResponseWriter, r *http.Request) {
 	companies, err := s.db.ListCompanies()
 	if err != nil {
@@ -126,20 +178,39 @@ func (s *Server) listCompanies(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) addCompany(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Name     string `json:"name"`
-		Platform string `json:"platform"`
-		Slug     string `json:"slug"`
+		Name      string `json:"name"`
+		Platform  string `json:"platform"`
+		Slug      string `json:"slug"`
+		CareerURL string `json:"career_url"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid JSON")
 		return
 	}
+	if req.Platform == "" && req.CareerURL != "" {
+		d, err := scraper.NewRegistry().DetectPublic(r.Context(), req.CareerURL)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		req.Platform, req.CareerURL = d.Platform, d.CareerURL
+		if req.Slug == "" {
+			req.Slug = d.Slug
+		}
+	}
+	if req.Slug == "" && req.CareerURL != "" {
+		if sc, err := scraper.NewRegistry().Get(req.Platform); err == nil {
+			if _, ok := sc.(scraper.CareerPageScraper); ok {
+				req.Slug = scraper.SlugFromCareerURL(req.CareerURL)
+			}
+		}
+	}
 	if req.Name == "" || req.Platform == "" || req.Slug == "" {
 		writeError(w, http.StatusBadRequest, "name, platform, and slug are required")
 		return
 	}
 
-	company, err := s.db.CreateCompany(req.Name, req.Platform, req.Slug, "")
+	company, err := s.db.CreateCompany(req.Name, req.Platform, req.Slug, req.CareerURL)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -147,6 +218,26 @@ func (s *Server) addCompany(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, company)
 }
 
+// detectCompany resolves a careers URL (e.g. the extension's current tab) to a
+// platform and slug without adding the company. Internal addresses are never
+// fetched.
+func (s *Server) detectCompany(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.URL == "" {
+		writeError(w, http.StatusBadRequest, "url is required")
+		return
+	}
+
+	d, err := scraper.NewRegistry().DetectPublic(r.Context(), req.URL)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
 func (s *Server) deleteCompany(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if err := s.db.DeleteCompany(id); err != nil {
@@ -219,6 +310,7 @@ func (s *Server) scanCart(w http.ResponseWriter, r *http.Request) {
 	registry := scraper.NewRegistry()
 	pool := worker.NewPool(registry, s.db, 5)
 	results := pool.Run(r.Context(), companies)
+	_, _ = dedup.Assign(s.db)
 
 	totalNew := 0
 	for _, res := range results {
@@ -227,15 +319,24 @@ func (s *Server) scanCart(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Score new jobs
+	// Classify new and revised jobs, then score them
+	unclassified, _ := s.db.ListUnclassifiedJobs()
+	for _, job := range unclassified {
+		expLevel, isNewGrad, visaMentioned, visaSentiment := h1b.ClassifyJob(job)
+		_ = s.db.UpdateJobClassification(job.ID, expLevel, isNewGrad, visaMentioned, visaSentiment)
+	}
+
 	profile, _ := s.db.GetProfile()
 	if profile != nil {
 		unscoredJobs, _ := s.db.ListUnscoredJobs()
 		pipeline := matcher.NewPipeline()
+		_ = pipeline.Index(s.db)
+		pipeline.Track(s.db)
 		for _, job := range unscoredJobs {
 			result := pipeline.Score(job, *profile)
 			_ = s.db.UpdateJobSkillScore(job.ID, result.Score, result.MatchedSkills, result.MissingSkills, result.Reason)
 		}
+		_, _ = ranking.Update(s.db, *profile)
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
@@ -257,9 +358,39 @@ func writeError(w http.ResponseWriter, status int, message string) {
 	writeJSON(w, status, map[string]string{"error": message})
 }
 
+// allowedOrigin reports whether a browser page at origin may call the API:
+// the Chrome extension, or an origin listed in server.allowed_origins.
+func allowedOrigin(origin string) bool {
+	if strings.HasPrefix(origin, "chrome-extension://") {
+		return true
+	}
+	for _, o := range viper.GetStringSlice("server.allowed_origins") {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// requireAllowedOrigin rejects browser requests from other origins. CORS
+// only hides the response from them; the request itself would still run.
+// Requests without an Origin, e.g. from curl, pass.
+func requireAllowedOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !allowedOrigin(origin) {
+			writeError(w, http.StatusForbidden, "origin not allowed: "+origin)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); allowedOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 		if r.Method == "OPTIONS" {
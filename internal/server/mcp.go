@@ -6,9 +6,15 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/dedup"
+	"github.com/Trungsherlock/jobgo/internal/salary"
 	"github.com/Trungsherlock/jobgo/internal/filter"
+	"github.com/Trungsherlock/jobgo/internal/freshness"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
+	"github.com/Trungsherlock/jobgo/internal/ranking"
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 )
@@ -35,12 +41,19 @@ func (m *MCPServer) registerTools() {
 	m.server.AddTool(
 		mcp.NewTool("search_jobs",
 			mcp.WithDescription("Search for jobs matching criteria. Returns a list of job postings with match scores."),
-			mcp.WithNumber("min_score", mcp.Description("Minimum match score (0-100)"), mcp.DefaultNumber(0)),
+			mcp.WithNumber("min_score", mcp.Description("Minimum final score (0-100)"), mcp.DefaultNumber(0)),
 			mcp.WithString("title", mcp.Description("Filter by job title (e.g. 'software engineer')")),
 			mcp.WithString("location", mcp.Description("Filter by location (e.g. 'US,remote')")),
 			mcp.WithBoolean("new_only", mcp.Description("Only return unseen jobs"), mcp.DefaultBool(false)),
 			mcp.WithBoolean("new_grad", mcp.Description("Only return new-grad friendly jobs"), mcp.DefaultBool(false)),
 			mcp.WithBoolean("h1b_only", mcp.Description("Only return jobs from H1B sponsors"), mcp.DefaultBool(false)),
+			mcp.WithBoolean("include_closed", mcp.Description("Also return postings that have been removed from their board"), mcp.DefaultBool(false)),
+			mcp.WithNumber("min_salary", mcp.Description("Minimum stated pay, annualized, in salary_currency; excludes jobs without a salary")),
+			mcp.WithString("salary_currency", mcp.Description("ISO currency code of min_salary (default USD); jobs paying in other currencies are excluded")),
+			mcp.WithBoolean("exclude_restricted", mcp.Description("Exclude jobs requiring US citizenship, a green card, a security clearance or export-control eligibility, or refusing visa sponsorship"), mcp.DefaultBool(false)),
+			mcp.WithString("employment_type", mcp.Description("Filter by employment type: full_time, part_time, contract, intern (e.g. 'intern,contract')")),
+			mcp.WithString("posted_within", mcp.Description("Only return jobs posted within this long (e.g. '7d', '2w', '36h')")),
+			mcp.WithString("sort", mcp.Description("Sort by 'rank' (final score blending skill, seniority, location, H1B and freshness; default), 'skill' or 'posted' (newest first)")),
 		),
 		m.searchJobs,
 	)
@@ -96,19 +109,34 @@ func (m *MCPServer) searchJobs(ctx context.Context, req mcp.CallToolRequest) (*m
 	newOnly, _ := args["new_only"].(bool)
 	newGrad, _ := args["new_grad"].(bool)
 	h1bOnly, _ := args["h1b_only"].(bool)
-
-	jobs, err := m.db.ListJobs(minScore, "", newOnly, false, false, false, false)
+	includeClosed, _ := args["include_closed"].(bool)
+	minSalary, _ := args["min_salary"].(float64)
+	salaryCurrency, _ := args["salary_currency"].(string)
+	employmentType, _ := args["employment_type"].(string)
+	excludeRestricted, _ := args["exclude_restricted"].(bool)
+	postedWithin, _ := args["posted_within"].(string)
+	sortKey, _ := args["sort"].(string)
+
+	jobs, err := m.db.QueryJobs(database.JobQuery{MinScore: minScore, OnlyNew: newOnly, IncludeClosed: includeClosed, MinSalary: minSalary, SalaryCurrency: salaryCurrency})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	params := filter.Params{NewGrad: newGrad, H1BOnly: h1bOnly}
+	params := filter.Params{NewGrad: newGrad, H1BOnly: h1bOnly, ExcludeRestricted: excludeRestricted}
 	if titleParam != "" {
 		params.Titles = strings.Split(titleParam, ",")
 	}
 	if locationParam != "" {
 		params.Locations = strings.Split(locationParam, ",")
 	}
+	if employmentType != "" {
+		params.EmploymentTypes = strings.Split(employmentType, ",")
+	}
+	if postedWithin != "" {
+		if params.PostedWithin, err = freshness.ParseAge(postedWithin); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 	var sponsorIDs map[string]bool
 	if h1bOnly {
 		companies, _ := m.db.ListCompanies()
@@ -119,7 +147,13 @@ func (m *MCPServer) searchJobs(ctx context.Context, req mcp.CallToolRequest) (*m
 			}
 		}
 	}
-	jobs = filter.Apply(jobs, filter.Build(params, sponsorIDs))
+	now := time.Now()
+	jobs, err = ranking.Sort(filter.Apply(jobs, filter.Build(params, sponsorIDs)), sortKey, now)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	jobs = dedup.Collapse(jobs)
+	halfLife := freshness.HalfLife()
 
 	// Build a concise summary for the AI
 	type jobSummary struct {
@@ -128,12 +162,19 @@ func (m *MCPServer) searchJobs(ctx context.Context, req mcp.CallToolRequest) (*m
 		Company  		string   	`json:"company"`
 		Location 		string   	`json:"location"`
 		Remote   		bool     	`json:"remote"`
+		FinalScore		*float64	`json:"final_score"`
 		SkillScore    	*float64 	`json:"skill_score"`
 		MatchedSkills   *string 	`json:"matched_skills,omitempty"`
 		MissingSkills   *string 	`json:"missing_skills,omitempty"`
 		Status   		string   	`json:"status"`
 		URL      		string   	`json:"url"`
 		IsNewGrad		bool		`json:"is_new_grad"`
+		ClosedAt		*time.Time	`json:"closed_at,omitempty"`
+		Duplicates		int			`json:"duplicates,omitempty"`
+		Salary			string		`json:"salary,omitempty"`
+		EmploymentType	*string		`json:"employment_type,omitempty"`
+		Restrictions	[]string	`json:"restrictions,omitempty"`
+		Posted			string		`json:"posted,omitempty"`
 	}
 
 	summaries := make([]jobSummary, 0, len(jobs))
@@ -142,17 +183,32 @@ func (m *MCPServer) searchJobs(ctx context.Context, req mcp.CallToolRequest) (*m
 		if j.Location != nil {
 			location = *j.Location
 		}
+		posted := ""
+		if age, ok := freshness.Age(j, now); ok {
+			posted = freshness.FormatAge(age) + " ago"
+		}
+		var final *float64
+		if v, ok := ranking.Current(j, halfLife, now); ok && j.FinalScore != nil {
+			final = &v
+		}
 		summaries = append(summaries, jobSummary{
 			ID:       		j.ID,
 			Title:    		j.Title,
 			Location: 		location,
 			Remote:   		j.Remote,
+			FinalScore:		final,
 			SkillScore:    	j.SkillScore,
 			MatchedSkills:  j.SkillMatched,
 			MissingSkills:  j.SkillMissing,
 			Status:   		j.Status,
 			URL:      		j.URL,
 			IsNewGrad: 		j.IsNewGrad,
+			ClosedAt: 		j.ClosedAt,
+			Duplicates:		j.DuplicateCount,
+			Salary:			salaryText(j),
+			EmploymentType:	j.EmploymentType,
+			Restrictions:	restrictionText(j),
+			Posted:			posted,
 		})
 	}
 
@@ -193,6 +249,10 @@ func (m *MCPServer) getJobDetails(ctx context.Context, req mcp.CallToolRequest)
 	if job.Description != nil {
 		details["description"] = *job.Description
 	}
+	if v, ok := ranking.Current(*job, freshness.HalfLife(), time.Now()); ok && job.FinalScore != nil {
+		details["final_score"] = v
+		details["score_breakdown"] = job.ScoreBreakdown
+	}
 	if job.SkillScore != nil {
 		details["skill_score"] = *job.SkillScore
 	}
@@ -213,6 +273,12 @@ func (m *MCPServer) getJobDetails(ctx context.Context, req mcp.CallToolRequest)
 	if job.ExperienceLevel != nil {
 		details["experience_level"] = *job.ExperienceLevel
 	}
+	if job.EmploymentType != nil {
+		details["employment_type"] = *job.EmploymentType
+	}
+	if r := h1b.RestrictionsOf(*job); len(r) > 0 {
+		details["restrictions"] = r
+	}
 	if c, err := m.db.GetCompany(job.CompanyID); err == nil {
 		details["sponsors_h1b"] = c.SponsorsH1b
 		if c.H1bApprovalRate != nil {
@@ -332,3 +398,20 @@ func (m *MCPServer) ServeSSE(port int) error {
 	fmt.Printf("MCP SSE server listening on http://localhost%s\n", addr)
 	return sseServer.Start(addr)
 }
+
+// salaryText is a job's stated pay, e.g. "$150K–200K/yr", or "".
+func salaryText(j database.Job) string {
+	if r, ok := salary.FromJob(j); ok {
+		return r.String()
+	}
+	return ""
+}
+
+// restrictionText lists a job's eligibility restrictions for the summary.
+func restrictionText(j database.Job) []string {
+	var out []string
+	for _, r := range h1b.RestrictionsOf(j) {
+		out = append(out, h1b.DescribeRestriction(r))
+	}
+	return out
+}
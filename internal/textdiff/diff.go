@@ -0,0 +1,122 @@
+// Package textdiff produces line diffs for showing how a posting changed.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op says whether a diff line is shared, removed or added.
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines diffs a against b using their longest common subsequence.
+func Lines(a, b []string) []Line {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []Line
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Line{Delete, a[i]})
+			i++
+		default:
+			out = append(out, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, Line{Insert, b[j]})
+	}
+	return out
+}
+
+// Unified renders the diff of a and b with "-" and "+" prefixes, keeping
+// context unchanged lines around each change and eliding the rest. It
+// returns "" when the texts are equal.
+func Unified(a, b string, context int) string {
+	diff := Lines(splitLines(a), splitLines(b))
+
+	// keep marks the lines within context of a change.
+	keep := make([]bool, len(diff))
+	changed := false
+	for i, l := range diff {
+		if l.Op == Equal {
+			continue
+		}
+		changed = true
+		for k := max(0, i-context); k <= min(len(diff)-1, i+context); k++ {
+			keep[k] = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	skipped := 0
+	for i, l := range diff {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		if skipped > 0 {
+			fmt.Fprintf(&sb, "  ... %d unchanged lines\n", skipped)
+			skipped = 0
+		}
+		switch l.Op {
+		case Delete:
+			sb.WriteString("- ")
+		case Insert:
+			sb.WriteString("+ ")
+		default:
+			sb.WriteString("  ")
+		}
+		sb.WriteString(l.Text)
+		sb.WriteByte('\n')
+	}
+	if skipped > 0 {
+		fmt.Fprintf(&sb, "  ... %d unchanged lines\n", skipped)
+	}
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
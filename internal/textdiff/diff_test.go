@@ -0,0 +1,42 @@
+package textdiff
+
+import "testing"
+
+func TestLines(t *testing.T) {
+	a := []string{"intro", "Go", "SQL", "outro"}
+	b := []string{"intro", "Go", "Kubernetes", "outro", "benefits"}
+
+	got := Lines(a, b)
+	want := []Line{
+		{Equal, "intro"},
+		{Equal, "Go"},
+		{Delete, "SQL"},
+		{Insert, "Kubernetes"},
+		{Equal, "outro"},
+		{Insert, "benefits"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8"
+	b := "1\n2\n3\n4\n5\n6\n7\nchanged"
+
+	want := "  ... 6 unchanged lines\n  7\n- 8\n+ changed\n"
+	if got := Unified(a, b, 1); got != want {
+		t.Errorf("Unified =\n%s\nwant\n%s", got, want)
+	}
+	if got := Unified(a, a, 1); got != "" {
+		t.Errorf("Unified of equal texts = %q, want empty", got)
+	}
+	if got := Unified("", "new", 3); got != "+ new\n" {
+		t.Errorf("Unified from empty = %q", got)
+	}
+}
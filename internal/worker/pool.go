@@ -2,16 +2,36 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/geo"
+	"github.com/Trungsherlock/jobgo/internal/h1b"
+	"github.com/Trungsherlock/jobgo/internal/htmltext"
+	"github.com/Trungsherlock/jobgo/internal/salary"
 	"github.com/Trungsherlock/jobgo/internal/scraper"
 )
 
 type Result struct {
 	Company 	database.Company
 	JobCount	int
+	// Unchanged is set when the board was not modified since the last
+	// scrape, either by a 304 or by an identical content hash.
+	Unchanged	bool
+	// Closed and Reopened count jobs that left or came back to the board.
+	Closed		int
+	Reopened	int
+	// Revised counts existing jobs whose content changed on this scrape.
+	Revised		int
+	// Partial is set when the scraper could not reach every posting, so
+	// jobs missing from this scrape were not closed.
+	Partial		bool
+	// Failed counts postings that could not be saved. The board's hash and
+	// validators are then left as they were, so the next run fetches and
+	// saves it again in full.
+	Failed		int
 	Err 		error
 }
 
@@ -76,36 +96,95 @@ func (p *Pool) scrapeCompany(ctx context.Context, company database.Company) Resu
 		return Result{Company: company, Err: err}
 	}
 
-	rawJobs, err := s.FetchJobs(ctx, company.Slug)
+	target := scraper.Target(s, company.Slug, company.CareerURL)
+	validators := scraper.Validators{ETag: company.ETag, LastModified: company.LastModified}
+	var rawJobs []scraper.RawJob
+	if cs, ok := s.(scraper.ConditionalScraper); ok {
+		rawJobs, validators, err = cs.FetchJobsIfChanged(ctx, target, validators)
+	} else {
+		rawJobs, err = s.FetchJobs(ctx, target)
+	}
+	partial := scraper.IsPartial(err)
+	if partial {
+		err = nil
+	}
+	if errors.Is(err, scraper.ErrNotModified) {
+		_ = p.db.UpdateCompanyLastScraped(company.ID)
+		return Result{Company: company, Unchanged: true}
+	}
 	if err != nil {
 		return Result{Company: company, Err: fmt.Errorf("scraping %s: %w", company.Name, err)}
 	}
 
-	newCount := 0
+	hash := scraper.ContentHash(rawJobs)
+	if hash == company.ContentHash {
+		_ = p.db.UpdateCompanyBoardState(company.ID, validators.ETag, validators.LastModified, hash)
+		_ = p.db.UpdateCompanyLastScraped(company.ID)
+		return Result{Company: company, Unchanged: true}
+	}
+
+	newCount, revised, failed := 0, 0, 0
 	for _, rj := range rawJobs {
-		created, err := p.db.CreateJob(
-			company.ID,
-			rj.ExternalID,
-			rj.Title,
-			rj.Description,
-			rj.Location,
-			rj.Department,
-			"",
-			rj.URL,
-			rj.Remote,
-			rj.PostedAt,
-		)
+		description := htmltext.ToText(rj.Description)
+		var pay *database.Salary
+		if r, ok := salary.Extract(rj.Compensation, description); ok {
+			pay = &database.Salary{Min: r.Min, Max: r.Max, Currency: r.Currency, Period: r.Period}
+		}
+		created, changed, err := p.db.UpsertJob(database.JobInput{
+			CompanyID:   company.ID,
+			ExternalID:  rj.ExternalID,
+			Title:       rj.Title,
+			Description: description,
+			Location:    rj.Location,
+			Department:  rj.Department,
+			URL:         rj.URL,
+			Remote:      rj.Remote,
+			PostedAt:    rj.PostedAt,
+			UpdatedAt:   rj.UpdatedAt,
+			Salary:      pay,
+			Places:      geo.Parse(rj.Location, rj.Remote),
+
+			OriginalDescription:    rj.Description,
+			PlatformEmploymentType: rj.EmploymentType,
+			EmploymentType:         h1b.ClassifyEmploymentType(rj.EmploymentType, rj.Title, description),
+			Restrictions:           h1b.DetectRestrictions(rj.Title, description),
+			RawPayload:             rj.Raw,
+		})
 		if err != nil {
+			failed++
 			continue
 		}
 		if created {
 			newCount++
 		}
+		if changed {
+			revised++
+		}
+	}
+
+	// An empty result is more often a broken scraper or a board mid-deploy
+	// than every role closing at once, so it never closes jobs; nor does a
+	// partial crawl, which would close whatever it failed to reach.
+	var closed, reopened int
+	if len(rawJobs) > 0 && !partial {
+		ids := make([]string, len(rawJobs))
+		for i, rj := range rawJobs {
+			ids[i] = rj.ExternalID
+		}
+		closed, reopened, _ = p.db.SyncOpenJobs(company.ID, ids)
 	}
 
+	if failed == 0 {
+		_ = p.db.UpdateCompanyBoardState(company.ID, validators.ETag, validators.LastModified, hash)
+	}
 	_ = p.db.UpdateCompanyLastScraped(company.ID)
 	return Result{
 		Company: company,
 		JobCount: newCount,
+		Closed: closed,
+		Reopened: reopened,
+		Revised: revised,
+		Partial: partial,
+		Failed: failed,
 	}
 }
\ No newline at end of file
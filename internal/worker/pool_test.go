@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/scraper"
+)
+
+type fakeScraper struct {
+	jobs []scraper.RawJob
+	err  error
+}
+
+func (f *fakeScraper) Name() string { return "fake" }
+
+func (f *fakeScraper) FetchJobs(ctx context.Context, slug string) ([]scraper.RawJob, error) {
+	return f.jobs, f.err
+}
+
+func setupTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.New(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	if err := db.Migrate(filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestPoolSkipsUnchangedBoards(t *testing.T) {
+	db := setupTestDB(t)
+	if _, err := db.CreateCompany("Acme", "fake", "acme", ""); err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+
+	fake := &fakeScraper{jobs: []scraper.RawJob{{ExternalID: "1", Title: "Backend Engineer", URL: "https://acme.test/1"}}}
+	registry := scraper.NewRegistry()
+	registry.Register(fake)
+	pool := NewPool(registry, db, 1)
+
+	run := func() Result {
+		companies, _ := db.ListCompanies()
+		results := pool.Run(context.Background(), companies)
+		if len(results) != 1 || results[0].Err != nil {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+		return results[0]
+	}
+
+	if r := run(); r.Unchanged || r.JobCount != 1 {
+		t.Errorf("first run: unchanged=%v new=%d, want changed with 1 new job", r.Unchanged, r.JobCount)
+	}
+	if r := run(); !r.Unchanged {
+		t.Error("second run over the same postings should be unchanged")
+	}
+
+	fake.jobs = append(fake.jobs, scraper.RawJob{ExternalID: "2", Title: "Data Engineer", URL: "https://acme.test/2"})
+	if r := run(); r.Unchanged || r.JobCount != 1 {
+		t.Errorf("third run: unchanged=%v new=%d, want changed with 1 new job", r.Unchanged, r.JobCount)
+	}
+
+	fake.jobs = fake.jobs[1:]
+	if r := run(); r.Closed != 1 {
+		t.Errorf("posting removed from board: closed=%d, want 1", r.Closed)
+	}
+	fake.jobs = append(fake.jobs, scraper.RawJob{ExternalID: "3", Title: "SRE", URL: "https://acme.test/3"})
+	run()
+	fake.jobs, fake.err = fake.jobs[1:], &scraper.PartialError{Source: "acme", Reason: "1 pages failed to load"}
+	if r := run(); r.Closed != 0 || !r.Partial {
+		t.Errorf("partial crawl: closed=%d partial=%v, want nothing closed", r.Closed, r.Partial)
+	}
+	fake.err = nil
+
+	fake.jobs = nil
+	if r := run(); r.Closed != 0 {
+		t.Errorf("empty board should not close jobs, closed=%d", r.Closed)
+	}
+}
+
+func TestPoolRetriesBoardAfterFailedSave(t *testing.T) {
+	db := setupTestDB(t)
+	if _, err := db.CreateCompany("Acme", "fake", "acme", ""); err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	// Refuse to store one posting, as a full disk or locked database would.
+	if _, err := db.Exec(`CREATE TRIGGER refuse_bad BEFORE INSERT ON jobs WHEN NEW.external_id = 'bad'
+		BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeScraper{jobs: []scraper.RawJob{
+		{ExternalID: "1", Title: "Backend Engineer", URL: "https://acme.test/1"},
+		{ExternalID: "bad", Title: "Data Engineer", URL: "https://acme.test/bad"},
+	}}
+	registry := scraper.NewRegistry()
+	registry.Register(fake)
+	pool := NewPool(registry, db, 1)
+
+	companies, _ := db.ListCompanies()
+	if r := pool.Run(context.Background(), companies)[0]; r.Failed != 1 || r.JobCount != 1 {
+		t.Fatalf("first run: failed=%d new=%d, want 1 and 1", r.Failed, r.JobCount)
+	}
+
+	if _, err := db.Exec(`DROP TRIGGER refuse_bad`); err != nil {
+		t.Fatal(err)
+	}
+	companies, _ = db.ListCompanies()
+	r := pool.Run(context.Background(), companies)[0]
+	if r.Unchanged || r.JobCount != 1 {
+		t.Errorf("second run: unchanged=%v new=%d, want the failed posting saved", r.Unchanged, r.JobCount)
+	}
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/Trungsherlock/jobgo/internal/database"
+	"github.com/Trungsherlock/jobgo/internal/scraper"
+)
+
+// VerifyStatus classifies the outcome of scraping a company once.
+type VerifyStatus string
+
+const (
+	VerifyOK           VerifyStatus = "ok"
+	VerifyNotFound     VerifyStatus = "not_found"
+	VerifyEmpty        VerifyStatus = "empty"
+	VerifyRateLimited  VerifyStatus = "rate_limited"
+	VerifyNetworkError VerifyStatus = "network_error"
+	// VerifyError covers everything else: unknown platform, bad scraper
+	// config, undecodable responses.
+	VerifyError VerifyStatus = "error"
+)
+
+type Verification struct {
+	Company  database.Company
+	Status   VerifyStatus
+	JobCount int
+	Err      error
+}
+
+// Classify maps a scrape outcome to a VerifyStatus.
+func Classify(jobCount int, err error) VerifyStatus {
+	if err == nil {
+		if jobCount == 0 {
+			return VerifyEmpty
+		}
+		return VerifyOK
+	}
+
+	var statusErr *scraper.StatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone:
+			return VerifyNotFound
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			return VerifyRateLimited
+		case statusErr.StatusCode >= 500:
+			return VerifyNetworkError
+		}
+		return VerifyError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return VerifyNetworkError
+	}
+	return VerifyError
+}
+
+// Verify scrapes every company once without storing jobs and classifies the
+// result.
+func (p *Pool) Verify(ctx context.Context, companies []database.Company) []Verification {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []Verification
+	)
+
+	queue := make(chan database.Company, len(companies))
+	for _, c := range companies {
+		queue <- c
+	}
+	close(queue)
+
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for company := range queue {
+				v := p.verifyCompany(ctx, company)
+				mu.Lock()
+				results = append(results, v)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (p *Pool) verifyCompany(ctx context.Context, company database.Company) Verification {
+	s, err := p.registry.Get(company.Platform)
+	if err != nil {
+		return Verification{Company: company, Status: VerifyError, Err: err}
+	}
+
+	rawJobs, err := s.FetchJobs(ctx, scraper.Target(s, company.Slug, company.CareerURL))
+	if scraper.IsPartial(err) && len(rawJobs) > 0 {
+		// Some postings were reached, so the board works.
+		err = nil
+	}
+	return Verification{
+		Company:  company,
+		Status:   Classify(len(rawJobs), err),
+		JobCount: len(rawJobs),
+		Err:      err,
+	}
+}
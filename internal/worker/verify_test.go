@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/Trungsherlock/jobgo/internal/scraper"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		jobCount int
+		err      error
+		want     VerifyStatus
+	}{
+		{"ok", 3, nil, VerifyOK},
+		{"empty board", 0, nil, VerifyEmpty},
+		{"wrong slug", 0, fmt.Errorf("scraping: %w", &scraper.StatusError{Source: "lever API", StatusCode: 404}), VerifyNotFound},
+		{"rate limited", 0, &scraper.StatusError{Source: "lever API", StatusCode: 429}, VerifyRateLimited},
+		{"server error", 0, &scraper.StatusError{Source: "lever API", StatusCode: 503}, VerifyNetworkError},
+		{"forbidden", 0, &scraper.StatusError{Source: "lever API", StatusCode: 403}, VerifyError},
+		{"dns failure", 0, fmt.Errorf("fetching: %w", &net.DNSError{Err: "no such host", Name: "api.lever.co"}), VerifyNetworkError},
+		{"timeout", 0, context.DeadlineExceeded, VerifyNetworkError},
+		{"decode error", 0, errors.New("decoding lever response: unexpected EOF"), VerifyError},
+	}
+
+	for _, tt := range tests {
+		if got := Classify(tt.jobCount, tt.err); got != tt.want {
+			t.Errorf("%s: Classify = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
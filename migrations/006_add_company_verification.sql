@@ -0,0 +1,4 @@
+ALTER TABLE companies ADD COLUMN verify_status TEXT;
+ALTER TABLE companies ADD COLUMN verify_error TEXT;
+ALTER TABLE companies ADD COLUMN verified_at DATETIME;
+ALTER TABLE companies ADD COLUMN not_found_count INTEGER DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE companies ADD COLUMN etag TEXT;
+ALTER TABLE companies ADD COLUMN last_modified TEXT;
+ALTER TABLE companies ADD COLUMN content_hash TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE jobs ADD COLUMN closed_at DATETIME;
+CREATE INDEX IF NOT EXISTS idx_jobs_company_closed ON jobs(company_id, closed_at);
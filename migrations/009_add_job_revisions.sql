@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS job_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id TEXT NOT NULL REFERENCES jobs(id),
+    title TEXT NOT NULL,
+    description TEXT,
+    location TEXT,
+    department TEXT,
+    captured_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_job_revisions_job ON job_revisions(job_id, id);
@@ -0,0 +1,2 @@
+ALTER TABLE jobs ADD COLUMN canonical_id TEXT REFERENCES jobs(id);
+CREATE INDEX IF NOT EXISTS idx_jobs_canonical ON jobs(canonical_id);
@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD COLUMN salary_min REAL;
+ALTER TABLE jobs ADD COLUMN salary_max REAL;
+ALTER TABLE jobs ADD COLUMN salary_currency TEXT;
+ALTER TABLE jobs ADD COLUMN salary_period TEXT;
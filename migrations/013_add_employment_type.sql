@@ -0,0 +1,2 @@
+ALTER TABLE jobs ADD COLUMN platform_employment_type TEXT;
+ALTER TABLE jobs ADD COLUMN employment_type TEXT;
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN description_original TEXT;
@@ -0,0 +1,6 @@
+ALTER TABLE jobs ADD COLUMN first_seen_at DATETIME;
+ALTER TABLE jobs ADD COLUMN platform_updated_at DATETIME;
+UPDATE jobs SET first_seen_at = created_at;
+-- Greenhouse's updated_at was stored as the posting date.
+UPDATE jobs SET platform_updated_at = posted_at, posted_at = NULL
+WHERE company_id IN (SELECT id FROM companies WHERE platform = 'greenhouse');
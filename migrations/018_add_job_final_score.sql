@@ -0,0 +1,3 @@
+ALTER TABLE jobs ADD COLUMN final_score REAL;
+ALTER TABLE jobs ADD COLUMN score_breakdown TEXT;
+CREATE INDEX IF NOT EXISTS idx_jobs_final_score ON jobs(final_score);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_llm_usage_run ON llm_usage(run_id);